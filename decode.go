@@ -1,6 +1,10 @@
 package gojay
 
 import (
+	"context"
+	"database/sql"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -12,6 +16,7 @@ import (
 //
 // If a JSON value is not appropriate for a given target type, or if a JSON number
 // overflows the target type, UnmarshalArray skips that field and completes the unmarshaling as best it can.
+// Use UnmarshalPolicy to choose how such values are handled.
 func UnmarshalArray(data []byte, v UnmarshalerArray) error {
 	dec := borrowDecoder(nil, 0)
 	defer dec.Release()
@@ -34,6 +39,7 @@ func UnmarshalArray(data []byte, v UnmarshalerArray) error {
 //
 // If a JSON value is not appropriate for a given target type, or if a JSON number
 // overflows the target type, UnmarshalObject skips that field and completes the unmarshaling as best it can.
+// Use UnmarshalPolicy to choose how such values are handled.
 func UnmarshalObject(data []byte, v UnmarshalerObject) error {
 	dec := borrowDecoder(nil, 0)
 	defer dec.Release()
@@ -60,74 +66,87 @@ func UnmarshalObject(data []byte, v UnmarshalerObject) error {
 // Otherwise, Unmarshal unmarshals the JSON into the value pointed at by the pointer.
 // If the pointer is nil, Unmarshal allocates a new value for it to point to.
 //
-// To Unmarshal JSON into a struct, Unmarshal requires the struct to implement UnmarshalerObject.
+// To Unmarshal JSON into a struct, Unmarshal requires the struct to implement UnmarshalerObject
+// or UnmarshalerObjectIndex.
 //
 // To unmarshal a JSON array into a slice, Unmarshal requires the slice to implement UnmarshalerArray.
 //
+// To unmarshal a JSON array into a struct, Unmarshal requires the struct to implement UnmarshalerTuple.
+//
+// To unmarshal a JSON string into a type which isn't a string, Unmarshal requires the type
+// to implement encoding.TextUnmarshaler.
+//
+// The database/sql null types (sql.NullString, sql.NullInt64, sql.NullFloat64, sql.NullBool
+// and sql.NullTime) are decoded with Valid set to false for null.
+//
+// Other types implementing json.Unmarshaler are decoded with their UnmarshalJSON method.
+//
 // Unmarshal JSON does not allow yet to unmarshall an interface value
 // If a JSON value is not appropriate for a given target type, or if a JSON number
 // overflows the target type, Unmarshal skips that field and completes the unmarshaling as best it can.
 // If no more serious errors are encountered, Unmarshal returns an UnmarshalTypeError describing the earliest such error. In any case, it's not guaranteed that all the remaining fields following the problematic one will be unmarshaled into the target object.
 func Unmarshal(data []byte, v interface{}) error {
+	return unmarshal(data, v, ReportLast)
+}
+
+// UnmarshalPolicy is like Unmarshal but handles type mismatches according to the error policy p,
+// it accepts the same values as Unmarshal, UnmarshalObject and UnmarshalArray.
+//
+//	err := gojay.UnmarshalPolicy(data, user, gojay.CollectAll)
+//	if errs, ok := err.(gojay.MultiError); ok {
+//		// all the fields which couldn't be decoded
+//	}
+func UnmarshalPolicy(data []byte, v interface{}, p ErrorPolicy) error {
+	return unmarshal(data, v, p)
+}
+
+func unmarshal(data []byte, v interface{}, p ErrorPolicy) error {
 	var err error
-	var dec *Decoder
+	dec := borrowDecoder(nil, 0)
+	defer dec.Release()
+	dec.policy = p
+	dec.length = len(data)
+	switch v.(type) {
+	case *string, *int, *int32, *uint32, *int64, *uint64, *float64, *bool:
+		dec.data = data
+	default:
+		dec.data = make([]byte, len(data))
+		copy(dec.data, data)
+	}
 	switch vt := v.(type) {
 	case *string:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeString(vt)
 	case *int:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeInt(vt)
 	case *int32:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeInt32(vt)
 	case *uint32:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeUint32(vt)
 	case *int64:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeInt64(vt)
 	case *uint64:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeUint64(vt)
 	case *float64:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeFloat64(vt)
 	case *bool:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = data
 		err = dec.decodeBool(vt)
+	case UnmarshalerObjectIndex:
+		_, err = dec.decodeObjectIndex(vt)
 	case UnmarshalerObject:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = make([]byte, len(data))
-		copy(dec.data, data)
 		_, err = dec.decodeObject(vt)
+	case UnmarshalerTuple:
+		_, err = dec.decodeTuple(vt)
 	case UnmarshalerArray:
-		dec = borrowDecoder(nil, 0)
-		dec.length = len(data)
-		dec.data = make([]byte, len(data))
-		copy(dec.data, data)
 		_, err = dec.decodeArray(vt)
+	case *sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		err = dec.decodeSQLNull(vt)
+	case json.Unmarshaler:
+		err = dec.decodeJSONUnmarshaler(vt)
+	case encoding.TextUnmarshaler:
+		err = dec.decodeTextUnmarshaler(vt)
 	default:
 		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(vt).String()))
 	}
-	defer dec.Release()
 	if err != nil {
 		return err
 	}
@@ -158,6 +177,19 @@ type Decoder struct {
 	cursor   int
 	length   int
 	keysDone int
+	index    *structuralIndex
+	policy   ErrorPolicy
+	path     []pathElem
+	errs     MultiError
+	ctx      context.Context
+	watch    *ctxWatch
+	// lenient enables JSONC and JSON5 input, lenientRead is set once the input has been read and normalized
+	lenient     bool
+	lenientRead bool
+	// allowNonFinite enables NaN and Infinity literals
+	allowNonFinite bool
+	// quotedValues enables numbers and booleans encoded as JSON strings
+	quotedValues bool
 }
 
 // Decode reads the next JSON-encoded value from its input and stores it in the value pointed to by v.
@@ -167,31 +199,44 @@ func (dec *Decoder) Decode(v interface{}) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
+	dec.resetPolicy()
 	switch vt := v.(type) {
 	case *string:
-		return dec.decodeString(vt)
+		return dec.policyErr(dec.decodeString(vt))
 	case *int:
-		return dec.decodeInt(vt)
+		return dec.policyErr(dec.decodeInt(vt))
 	case *int32:
-		return dec.decodeInt32(vt)
+		return dec.policyErr(dec.decodeInt32(vt))
 	case *uint32:
-		return dec.decodeUint32(vt)
+		return dec.policyErr(dec.decodeUint32(vt))
 	case *int64:
-		return dec.decodeInt64(vt)
+		return dec.policyErr(dec.decodeInt64(vt))
 	case *uint64:
-		return dec.decodeUint64(vt)
+		return dec.policyErr(dec.decodeUint64(vt))
 	case *float64:
-		return dec.decodeFloat64(vt)
+		return dec.policyErr(dec.decodeFloat64(vt))
 	case *bool:
-		return dec.decodeBool(vt)
+		return dec.policyErr(dec.decodeBool(vt))
+	case UnmarshalerObjectIndex:
+		_, err := dec.decodeObjectIndex(vt)
+		return dec.policyErr(err)
 	case UnmarshalerObject:
 		_, err := dec.decodeObject(vt)
-		return err
+		return dec.policyErr(err)
+	case UnmarshalerTuple:
+		_, err := dec.decodeTuple(vt)
+		return dec.policyErr(err)
 	case UnmarshalerArray:
 		_, err := dec.decodeArray(vt)
-		return err
+		return dec.policyErr(err)
 	case *EmbeddedJSON:
-		return dec.decodeEmbeddedJSON(vt)
+		return dec.policyErr(dec.decodeEmbeddedJSON(vt))
+	case *sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		return dec.policyErr(dec.decodeSQLNull(vt))
+	case json.Unmarshaler:
+		return dec.policyErr(dec.decodeJSONUnmarshaler(vt))
+	case encoding.TextUnmarshaler:
+		return dec.policyErr(dec.decodeTextUnmarshaler(vt))
 	default:
 		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(vt).String()))
 	}
@@ -286,6 +331,9 @@ func isDigit(b byte) bool {
 
 func (dec *Decoder) read() bool {
 	if dec.r != nil {
+		if dec.lenient {
+			return dec.readLenient()
+		}
 		// if we reach the end, double the buffer to ensure there's always more space
 		if len(dec.data) == dec.length {
 			nLen := dec.length * 2
@@ -296,6 +344,14 @@ func (dec *Decoder) read() bool {
 		var n int
 		var err error
 		for n == 0 {
+			// stop reading if the context is done
+			if dec.ctx != nil {
+				if err = dec.ctx.Err(); err != nil {
+					dec.err = err
+					return false
+				}
+				dec.watchContext()
+			}
 			n, err = dec.r.Read(dec.data[dec.length:])
 			if err != nil {
 				if err != io.EOF {
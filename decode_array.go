@@ -13,8 +13,9 @@ func (dec *Decoder) DecodeArray(arr UnmarshalerArray) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
+	dec.resetPolicy()
 	_, err := dec.decodeArray(arr)
-	return err
+	return dec.policyErr(err)
 }
 func (dec *Decoder) decodeArray(arr UnmarshalerArray) (int, error) {
 	// not an array not an error, but do not know what to do
@@ -25,18 +26,36 @@ func (dec *Decoder) decodeArray(arr UnmarshalerArray) (int, error) {
 			continue
 		case '[':
 			n := 0
+			depth := len(dec.path)
 			dec.cursor = dec.cursor + 1
 			// array is open, char is not space start readings
-			for dec.nextChar() != 0 {
+			for {
+				// with a structural index, jump straight to the next separator
+				if dec.index != nil {
+					switch dec.indexedSeparator() {
+					case ']':
+						return dec.cursor, nil
+					case ',':
+						continue
+					}
+				}
+				if dec.nextChar() == 0 {
+					break
+				}
 				// closing array
 				if dec.data[dec.cursor] == ']' {
 					dec.cursor = dec.cursor + 1
 					return dec.cursor, nil
 				}
+				if dec.tracksPath() {
+					dec.path = append(dec.path[:depth], pathElem{index: n})
+				}
 				// calling unmarshall function for each element of the slice
 				err := arr.UnmarshalArray(dec)
 				if err != nil {
 					return 0, err
+				} else if dec.failed() {
+					return 0, dec.err
 				}
 				n++
 			}
@@ -52,14 +71,13 @@ func (dec *Decoder) decodeArray(arr UnmarshalerArray) (int, error) {
 		case '{', '"', 'f', 't', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			// can't unmarshall to struct
 			// we skip array and set Error
-			dec.err = InvalidTypeError(
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to array, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return 0, err
 			}
@@ -72,6 +90,12 @@ func (dec *Decoder) decodeArray(arr UnmarshalerArray) (int, error) {
 }
 
 func (dec *Decoder) skipArray() (int, error) {
+	// with a structural index, jump straight to the closing bracket
+	if dec.index != nil {
+		if end, ok := dec.index.skip(dec.data, dec.cursor); ok {
+			return end, nil
+		}
+	}
 	var arraysOpen = 1
 	var arraysClosed = 0
 	// var stringOpen byte = 0
@@ -0,0 +1,108 @@
+package gojay
+
+import "fmt"
+
+// UnmarshalAtomic decodes the JSON object in data to a fresh value returned by newV.
+// The document is validated first, then decoded, and the value is returned only
+// if decoding completes without any error. A value in use is never left half populated:
+//
+//	v, err := gojay.UnmarshalAtomic(data, func() gojay.UnmarshalerObject { return &Config{} })
+//	if err != nil {
+//		return err
+//	}
+//	config.Store(v.(*Config))
+//
+// Type mismatches are errors, unless they are ignored with the IgnoreMismatches policy.
+func UnmarshalAtomic(data []byte, newV func() UnmarshalerObject) (UnmarshalerObject, error) {
+	dec := borrowDecoder(nil, 0)
+	defer dec.Release()
+	dec.length = len(data)
+	dec.data = make([]byte, len(data))
+	copy(dec.data, data)
+	end, err := dec.validate()
+	if err != nil {
+		return nil, err
+	}
+	if end = dec.validateSpace(end); end < dec.length {
+		return nil, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[end], end))
+	}
+	return dec.decodeObjectAtomic(newV, end)
+}
+
+// UnmarshalDryRun validates the JSON object in data and decodes it to a fresh value returned by newV,
+// which is then dropped, to report all the errors found. It can be used to check a document before applying it.
+//
+// Type mismatches are collected as with the CollectAll policy, a syntax error is appended to them.
+// If there are several errors, the returned error is a MultiError.
+func UnmarshalDryRun(data []byte, newV func() UnmarshalerObject) error {
+	dec := borrowDecoder(nil, 0)
+	defer dec.Release()
+	dec.length = len(data)
+	dec.data = make([]byte, len(data))
+	copy(dec.data, data)
+	dec.policy = CollectAll
+	end, err := dec.validate()
+	if err == nil {
+		if end = dec.validateSpace(end); end < dec.length {
+			err = InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[end], end))
+		}
+	}
+	// decode even if the document is invalid to collect the mismatches found before the syntax error
+	_, decErr := dec.decodeObject(newV())
+	if err == nil {
+		err = decErr
+	}
+	if err != nil {
+		if len(dec.errs) > 0 {
+			return append(dec.errs, err)
+		}
+		return err
+	}
+	return dec.err
+}
+
+// DecodeObjectAtomic reads the next JSON-encoded object from its input to a fresh value returned by newV.
+// The object is validated first, then decoded, and the value is returned only if decoding completes
+// without any error. If validation fails, the input is not consumed.
+//
+// Type mismatches are errors, unless they are ignored with the IgnoreMismatches policy.
+func (dec *Decoder) DecodeObjectAtomic(newV func() UnmarshalerObject) (UnmarshalerObject, error) {
+	if dec.isPooled == 1 {
+		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
+	}
+	dec.path = dec.path[:0]
+	end, err := dec.validate()
+	if err != nil {
+		return nil, dec.ctxErr(err)
+	}
+	v, err := dec.decodeObjectAtomic(newV, end)
+	return v, dec.ctxErr(err)
+}
+
+// decodeObjectAtomic decodes the next object, validated up to end, to a fresh value returned by newV.
+// It returns it only if no error, including mismatches, occurred.
+func (dec *Decoder) decodeObjectAtomic(newV func() UnmarshalerObject, end int) (UnmarshalerObject, error) {
+	prevErr, nErrs := dec.err, len(dec.errs)
+	dec.err = nil
+	v := newV()
+	l := dec.length
+	_, err := dec.decodeObject(v)
+	// unescaping strings shifts the rest of the buffer to the left
+	if d := l - dec.length; d > 0 {
+		end -= d
+	}
+	// decoding may stop before the end of the object, once all keys are found or on error,
+	// move to the end so that the next value can be decoded
+	dec.cursor = end
+	if err != nil {
+		return nil, err
+	}
+	if len(dec.errs) > nErrs {
+		return nil, dec.errs[nErrs:]
+	}
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	dec.err = prevErr
+	return v, nil
+}
@@ -0,0 +1,135 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestObj() UnmarshalerObject {
+	return &TestObj{}
+}
+
+func TestUnmarshalAtomic(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v, err := UnmarshalAtomic([]byte(`{"test": 1, "test3": "a\"b"}`), newTestObj)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.(*TestObj).test, "v.test is not expected value")
+		assert.Equal(t, `a"b`, v.(*TestObj).test3, "v.test3 is not expected value")
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		v, err := UnmarshalAtomic([]byte(`{"test": 1, "test2": "str"}`), newTestObj)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Nil(t, v, "v should be nil")
+	})
+	t.Run("mismatch-first-key", func(t *testing.T) {
+		v, err := UnmarshalAtomic([]byte(`{"test": "a", "test2": 2}`), newTestObj)
+		assert.NotNil(t, err, "err should not be nil")
+		assert.Nil(t, v, "v should be nil")
+	})
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{name: "truncated", json: `{"test": 1, "test3": "str"`},
+		{name: "truncated-string", json: `{"test": 1, "test3": "str`},
+		{name: "missing-colon", json: `{"test" 1}`},
+		{name: "missing-comma", json: `{"test": 1 "test2": 2}`},
+		{name: "trailing-comma", json: `{"test": 1,}`},
+		{name: "invalid-literal", json: `{"test": nul}`},
+		{name: "invalid-number", json: `{"test": 01}`},
+		{name: "invalid-exponent", json: `{"test": 1e}`},
+		{name: "invalid-escape", json: `{"test3": "\x"}`},
+		{name: "unclosed-array", json: `{"testArr": [{}, {}}`},
+		{name: "trailing-data", json: `{"test": 1} {}`},
+		{name: "nan", json: `{"test5": NaN}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v, err := UnmarshalAtomic([]byte(testCase.json), newTestObj)
+			assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+			assert.Nil(t, v, "v should be nil")
+		})
+	}
+}
+
+func TestUnmarshalDryRun(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		err := UnmarshalDryRun([]byte(`{"test": 1, "test3": "str", "testArr": [{"test": 1}, null]}`), newTestObj)
+		assert.Nil(t, err, "err should be nil")
+	})
+	t.Run("mismatches", func(t *testing.T) {
+		err := UnmarshalDryRun([]byte(`{"test": "a", "test2": "b", "test3": "str"}`), newTestObj)
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		assert.Len(t, err, 2, "all mismatches should be reported")
+		assert.Equal(t, "$.test2", err.(MultiError)[1].(MismatchError).Path, "path is not expected value")
+	})
+	t.Run("mismatch-and-invalid-json", func(t *testing.T) {
+		err := UnmarshalDryRun([]byte(`{"test": "a", "test3": "str"`), newTestObj)
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		assert.Len(t, err, 2, "all errors should be reported")
+		assert.IsType(t, InvalidJSONError(""), err.(MultiError)[1], "last error should be of type InvalidJSONError")
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		err := UnmarshalDryRun([]byte(`{"test": 1,, "test3": "str"}`), newTestObj)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+	t.Run("data-not-mutated", func(t *testing.T) {
+		data := []byte(`{"test3": "a\"b"}`)
+		err := UnmarshalDryRun(data, newTestObj)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"test3": "a\"b"}`, string(data), "data should not be mutated")
+	})
+}
+
+func TestDecoderDecodeObjectAtomic(t *testing.T) {
+	t.Run("stream", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": 1} {"test": "a", "test2": 2} {"test": 3}`))
+		v, err := dec.DecodeObjectAtomic(newTestObj)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.(*TestObj).test, "v.test is not expected value")
+		v, err = dec.DecodeObjectAtomic(newTestObj)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Nil(t, v, "v should be nil")
+		v, err = dec.DecodeObjectAtomic(newTestObj)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 3, v.(*TestObj).test, "v.test is not expected value")
+	})
+	t.Run("nested-values", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": 1, "a": 1, "b": {"c": [1]}} {"test": 2}`))
+		v, err := dec.DecodeObjectAtomic(func() UnmarshalerObject { return &TestSubObj{} })
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.(*TestSubObj).test3, "v.test3 is not expected value")
+		v, err = dec.DecodeObjectAtomic(func() UnmarshalerObject { return &TestSubObj{} })
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, v.(*TestSubObj).test3, "v.test3 is not expected value")
+	})
+	t.Run("collect-all", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": "a"} {"test2": "b", "test": "c"}`))
+		dec.SetErrorPolicy(CollectAll)
+		_, err := dec.DecodeObjectAtomic(newTestObj)
+		assert.Len(t, err, 1, "err should hold 1 error")
+		_, err = dec.DecodeObjectAtomic(newTestObj)
+		assert.Len(t, err, 2, "err should only hold the errors of the last object")
+	})
+	t.Run("ignore-mismatches", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": "a", "test2": 2}`))
+		dec.SetErrorPolicy(IgnoreMismatches)
+		v, err := dec.DecodeObjectAtomic(newTestObj)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, v.(*TestObj).test2, "v.test2 is not expected value")
+	})
+	t.Run("non-finite", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test5": -Infinity}`))
+		dec.SetAllowNonFinite(true)
+		_, err := dec.DecodeObjectAtomic(newTestObj)
+		assert.Nil(t, err, "err should be nil")
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": 1`))
+		v, err := dec.DecodeObjectAtomic(newTestObj)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		assert.Nil(t, v, "v should be nil")
+	})
+}
@@ -9,7 +9,8 @@ func (dec *Decoder) DecodeBool(v *bool) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeBool(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeBool(v))
 }
 func (dec *Decoder) decodeBool(v *bool) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -42,14 +43,23 @@ func (dec *Decoder) decodeBool(v *bool) error {
 			dec.cursor++
 			return nil
 		default:
-			dec.err = InvalidUnmarshalError(
+			if dec.data[dec.cursor] == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedBool()
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = val
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidUnmarshalError(
 				fmt.Sprintf(
 					"Cannot unmarshall to bool, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,106 @@
+package gojay
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// NewDecoderContext returns a new decoder reading from r until ctx is done.
+//
+// The context is checked before each read, once it is done decoding stops
+// and Decode methods return ctx.Err().
+// If r implements SetReadDeadline(time.Time) error (like net.Conn), a pending read is also
+// interrupted once ctx is done: while a Decode method reads from r, the deadline of ctx is set on r
+// and a deadline in the past is set when ctx is canceled. If the decoder did set a deadline,
+// it is cleared when the Decode method returns so that r can be used afterwards,
+// otherwise a deadline set by the caller on r is left untouched.
+func NewDecoderContext(ctx context.Context, r io.Reader) *Decoder {
+	dec := NewDecoder(r)
+	dec.setContext(ctx)
+	return dec
+}
+
+// BorrowDecoderContext borrows a Decoder from the pool reading from r until ctx is done.
+//
+// See NewDecoderContext for details about how ctx is used.
+func BorrowDecoderContext(ctx context.Context, r io.Reader) *Decoder {
+	dec := borrowDecoder(r, 512)
+	dec.setContext(ctx)
+	return dec
+}
+
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// ctxWatch interrupts the pending reads of a readDeadliner once a context is done.
+type ctxWatch struct {
+	rd   readDeadliner
+	stop chan struct{}
+	done chan struct{}
+	// deadline is true if a deadline was set on rd, it is only read once done is closed
+	deadline bool
+}
+
+func (dec *Decoder) setContext(ctx context.Context) {
+	dec.ctx = ctx
+}
+
+// watchContext starts interrupting pending reads once the decoder's context is done,
+// if the reader supports read deadlines. It is called before reading and
+// stopped by unwatchContext when the Decode method returns.
+func (dec *Decoder) watchContext() {
+	if dec.watch != nil || dec.ctx == nil || dec.ctx.Done() == nil {
+		return
+	}
+	rd, ok := dec.r.(readDeadliner)
+	if !ok {
+		return
+	}
+	w := &ctxWatch{rd: rd, stop: make(chan struct{}), done: make(chan struct{})}
+	if d, ok := dec.ctx.Deadline(); ok {
+		rd.SetReadDeadline(d)
+		w.deadline = true
+	}
+	dec.watch = w
+	ctxDone := dec.ctx.Done()
+	// like context.AfterFunc, which isn't available in all supported Go versions
+	go func() {
+		defer close(w.done)
+		select {
+		case <-ctxDone:
+			w.rd.SetReadDeadline(time.Now())
+			w.deadline = true
+		case <-w.stop:
+		}
+	}()
+}
+
+// unwatchContext stops watching the context and clears the read deadline if it was set
+// by the watcher, so that it doesn't apply to later reads on the reader.
+func (dec *Decoder) unwatchContext() {
+	w := dec.watch
+	if w == nil {
+		return
+	}
+	dec.watch = nil
+	close(w.stop)
+	<-w.done
+	if w.deadline {
+		w.rd.SetReadDeadline(time.Time{})
+	}
+}
+
+// ctxErr returns the error of the decoder's context if decoding
+// was stopped because it is done, err otherwise.
+// It is called when a Decode method returns and stops watching the context.
+func (dec *Decoder) ctxErr(err error) error {
+	if dec.ctx != nil {
+		dec.unwatchContext()
+		if ctxErr := dec.ctx.Err(); ctxErr != nil && (err != nil || dec.err == ctxErr) {
+			return ctxErr
+		}
+	}
+	return err
+}
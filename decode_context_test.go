@@ -0,0 +1,187 @@
+package gojay
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testChunkReader returns one chunk per call to Read and calls onRead after each of them
+type testChunkReader struct {
+	chunks   []string
+	onRead   func(n int)
+	reads    int
+	deadline time.Time
+}
+
+func (r *testChunkReader) Read(b []byte) (int, error) {
+	if r.reads == len(r.chunks) {
+		return 0, nil
+	}
+	n := copy(b, r.chunks[r.reads])
+	r.reads++
+	if r.onRead != nil {
+		r.onRead(r.reads)
+	}
+	return n, nil
+}
+
+func (r *testChunkReader) SetReadDeadline(t time.Time) error {
+	r.deadline = t
+	return nil
+}
+
+func TestDecoderContext(t *testing.T) {
+	t.Run("cancel-between-reads", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		r := &testChunkReader{
+			chunks: []string{`{"test": 1, `, `"test2": 2}`},
+			onRead: func(n int) {
+				cancel()
+			},
+		}
+		dec := NewDecoderContext(ctx, r)
+		v := &TestObj{}
+		err := dec.DecodeObject(v)
+		assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+		assert.Equal(t, 1, r.reads, "reader should not be read once context is canceled")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+	})
+	t.Run("canceled-before-decoding", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		testCases := []struct {
+			name string
+			v    interface{}
+		}{
+			{name: "string", v: new(string)},
+			{name: "int", v: new(int)},
+			{name: "bool", v: new(bool)},
+			{name: "object", v: &TestObj{}},
+			{name: "array", v: new(testSliceStrings)},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				dec := BorrowDecoderContext(ctx, strings.NewReader(`"test"`))
+				defer dec.Release()
+				err := dec.Decode(testCase.v)
+				assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+			})
+		}
+	})
+	t.Run("deadline-propagation", func(t *testing.T) {
+		d := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithDeadline(context.Background(), d)
+		defer cancel()
+		r := &testChunkReader{chunks: []string{`{"test": 1}`}}
+		r.onRead = func(n int) {
+			assert.True(t, d.Equal(r.deadline), "deadline should be set on the reader while reading")
+		}
+		dec := BorrowDecoderContext(ctx, r)
+		defer dec.Release()
+		v := &TestObj{}
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+		assert.True(t, r.deadline.IsZero(), "deadline should be cleared once decoding is done")
+	})
+	t.Run("caller-deadline-kept", func(t *testing.T) {
+		d := time.Now().Add(time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		r := &testChunkReader{chunks: []string{`{"test": 1}`}, deadline: d}
+		dec := BorrowDecoderContext(ctx, r)
+		defer dec.Release()
+		v := &TestObj{}
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+		assert.True(t, d.Equal(r.deadline), "deadline set by the caller should be kept")
+	})
+	t.Run("cancel-pending-read", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			server.Write([]byte(`{"test": 1, `))
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		dec := NewDecoderContext(ctx, client)
+		v := &TestObj{}
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- dec.DecodeObject(v)
+		}()
+		select {
+		case err := <-errChan:
+			assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+		case <-time.After(5 * time.Second):
+			t.Fatal("pending read should be interrupted when the context is canceled")
+		}
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+	})
+	t.Run("deadline-cleared-for-next-read", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		go server.Write([]byte(`1 `))
+		dec := BorrowDecoderContext(ctx, client)
+		v := 0
+		err := dec.DecodeInt(&v)
+		assert.Nil(t, err, "err should be nil")
+		dec.Release()
+		time.Sleep(40 * time.Millisecond)
+		// the connection is kept alive and read without the context
+		go server.Write([]byte(`2`))
+		b := make([]byte, 1)
+		_, err = client.Read(b)
+		assert.Nil(t, err, "deadline of the context should not apply to later reads")
+		assert.Equal(t, "2", string(b), "b is not expected value")
+	})
+	t.Run("release-stops-watching", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := &testChunkReader{chunks: []string{`{"test": `}}
+		dec := BorrowDecoderContext(ctx, r)
+		dec.watchContext()
+		assert.NotNil(t, dec.watch, "dec.watch should not be nil")
+		dec.Release()
+		cancel()
+		time.Sleep(time.Millisecond)
+		assert.True(t, r.deadline.IsZero(), "deadline should be cleared on release")
+	})
+	t.Run("deadline-exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		r := &testChunkReader{
+			chunks: []string{`[`, `"a"`, `]`},
+			onRead: func(n int) {
+				time.Sleep(5 * time.Millisecond)
+			},
+		}
+		dec := NewDecoderContext(ctx, r)
+		v := testSliceStrings{}
+		err := dec.DecodeArray(&v)
+		assert.Equal(t, context.DeadlineExceeded, err, "err should be context.DeadlineExceeded")
+	})
+	t.Run("pooled-decoder-reset", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		dec := BorrowDecoderContext(ctx, strings.NewReader(`1`))
+		dec.Release()
+		dec = BorrowDecoder(strings.NewReader(`1`))
+		defer dec.Release()
+		v := 0
+		err := dec.DecodeInt(&v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v, "v should be 1")
+	})
+}
@@ -9,7 +9,7 @@ func (dec *Decoder) decodeEmbeddedJSON(ej *EmbeddedJSON) error {
 	if ej == nil {
 		return InvalidUnmarshalError("Invalid nil pointer given")
 	}
-	var beginOfEmbeddedJSON int
+	var beginOfEmbeddedJSON = -1
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
 		switch dec.data[dec.cursor] {
 		case ' ', '\n', '\t', '\r', ',':
@@ -62,7 +62,7 @@ func (dec *Decoder) decodeEmbeddedJSON(ej *EmbeddedJSON) error {
 		break
 	}
 	if err == nil {
-		if dec.cursor-1 > beginOfEmbeddedJSON {
+		if beginOfEmbeddedJSON >= 0 && dec.cursor > beginOfEmbeddedJSON {
 			*ej = append(*ej, dec.data[beginOfEmbeddedJSON:dec.cursor]...)
 		}
 	}
@@ -0,0 +1,282 @@
+package gojay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// IndexedDecoder decodes a complete in-memory JSON document in two stages.
+//
+// The first stage builds a structural index holding the positions of all
+// the structural characters ({}[]:,") found outside of strings, along with
+// the position of the bracket or quote matching each opening one.
+// The second stage walks the index to drive UnmarshalObject and UnmarshalArray:
+// keys are read from the positions of their quotes and of the following colon,
+// separators between array elements are found from the index, and every ignored subtree
+// (unknown keys, objects left once NKeys keys are decoded, ...) is skipped
+// by jumping directly to its end. Values themselves are decoded by the regular Decoder methods.
+//
+// It embeds a *Decoder, therefore existing implementations of
+// UnmarshalerObject and UnmarshalerArray can be used as is.
+// It is worth using for large documents, for small ones prefer Unmarshal.
+type IndexedDecoder struct {
+	*Decoder
+}
+
+// NewIndexedDecoder runs the first stage on data and returns an IndexedDecoder
+// ready to decode it. data is copied and can be reused by the caller.
+//
+// If the structure of data is invalid (unbalanced brackets or unterminated string)
+// it returns an InvalidJSONError.
+func NewIndexedDecoder(data []byte) (*IndexedDecoder, error) {
+	ix, err := buildStructuralIndex(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := NewDecoder(nil)
+	dec.data = make([]byte, len(data))
+	copy(dec.data, data)
+	dec.length = len(data)
+	dec.index = ix
+	return &IndexedDecoder{Decoder: dec}, nil
+}
+
+// structuralIndex is the output of the first stage of an IndexedDecoder.
+//
+// pos holds the position of each structural character in the original data.
+// For opening brackets and quotes, match holds the index in pos
+// of the matching closing character, for others it holds its own index.
+type structuralIndex struct {
+	pos   []uint32
+	match []uint32
+	// next is the first entry which is not behind the decoder's cursor
+	next int
+	// shift is the number of bytes removed from the decoder's buffer while unescaping strings.
+	// Entries ahead of the cursor are at pos - shift in the current buffer.
+	shift int
+}
+
+const (
+	swarOnes = 0x0101010101010101
+	swarLows = 0x7f7f7f7f7f7f7f7f
+)
+
+// swarEq returns a word with the high bit of each byte set
+// where the byte of w is equal to c.
+func swarEq(w uint64, c byte) uint64 {
+	x := w ^ (swarOnes * uint64(c))
+	return ^(((x & swarLows) + swarLows) | x | swarLows)
+}
+
+func buildStructuralIndex(data []byte) (*structuralIndex, error) {
+	if uint64(len(data)) > math.MaxUint32 {
+		return nil, InvalidJSONError("Document too large to be indexed")
+	}
+	b := &indexBuilder{
+		ix: &structuralIndex{
+			pos:   make([]uint32, 0, len(data)>>3),
+			match: make([]uint32, 0, len(data)>>3),
+		},
+	}
+	l := len(data)
+	i := 0
+	// look at 8 bytes at once, most words don't hold any structural character
+	for ; i+8 <= l; i += 8 {
+		w := binary.LittleEndian.Uint64(data[i:])
+		m := swarEq(w, '"') | swarEq(w, '\\')
+		// inside a string only quotes and anti slashes matter
+		if b.inString && m == 0 {
+			continue
+		}
+		m |= swarEq(w, '{') | swarEq(w, '}') |
+			swarEq(w, '[') | swarEq(w, ']') |
+			swarEq(w, ':') | swarEq(w, ',')
+		for ; m != 0; m &= m - 1 {
+			if err := b.step(data, i+bits.TrailingZeros64(m)>>3); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for ; i < l; i++ {
+		switch data[i] {
+		case '"', '\\', '{', '}', '[', ']', ':', ',':
+			if err := b.step(data, i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if b.inString || len(b.stack) > 0 {
+		return nil, InvalidJSONError("Invalid JSON, unexpected end of input")
+	}
+	return b.ix, nil
+}
+
+type indexBuilder struct {
+	ix       *structuralIndex
+	stack    []uint32
+	inString bool
+	// skip is the position of the first char which is not escaped
+	skip int
+}
+
+func (b *indexBuilder) step(data []byte, j int) error {
+	if j < b.skip {
+		return nil
+	}
+	ix := b.ix
+	n := uint32(len(ix.pos))
+	c := data[j]
+	if b.inString {
+		switch c {
+		case '\\':
+			b.skip = j + 2
+		case '"':
+			b.inString = false
+			open := b.stack[len(b.stack)-1]
+			b.stack = b.stack[:len(b.stack)-1]
+			ix.match[open] = n
+			ix.pos = append(ix.pos, uint32(j))
+			ix.match = append(ix.match, n)
+		}
+		return nil
+	}
+	switch c {
+	case '"':
+		b.inString = true
+		b.stack = append(b.stack, n)
+	case '{', '[':
+		b.stack = append(b.stack, n)
+	case '}', ']':
+		if len(b.stack) == 0 {
+			return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, j))
+		}
+		open := b.stack[len(b.stack)-1]
+		if o := data[ix.pos[open]]; (c == '}' && o != '{') || (c == ']' && o != '[') {
+			return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, j))
+		}
+		b.stack = b.stack[:len(b.stack)-1]
+		ix.match[open] = n
+	case '\\':
+		return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, j))
+	}
+	ix.pos = append(ix.pos, uint32(j))
+	ix.match = append(ix.match, n)
+	return nil
+}
+
+// skip returns the position right after the end of the string, object or array
+// opened at p-1 or, if p is inside an object or array, right after the end of it.
+// data is the current buffer of the decoder.
+//
+// It returns false if p can't be found in the index.
+func (ix *structuralIndex) skip(data []byte, p int) (int, bool) {
+	if p < 1 {
+		return 0, false
+	}
+	op := p - 1 + ix.shift
+	for ix.next < len(ix.pos) && int(ix.pos[ix.next]) < op {
+		ix.next++
+	}
+	i := ix.next
+	// p is right after an opening char, jump to the matching one
+	if i < len(ix.pos) && int(ix.pos[i]) == op && int(ix.match[i]) > i {
+		m := int(ix.match[i])
+		ix.next = m + 1
+		return int(ix.pos[m]) - ix.shift + 1, true
+	}
+	// p is inside an object or array, jump over its children until its end
+	if i < len(ix.pos) && int(ix.pos[i]) == op {
+		i++
+	}
+	for i < len(ix.pos) {
+		if m := int(ix.match[i]); m > i {
+			i = m + 1
+			continue
+		}
+		switch data[int(ix.pos[i])-ix.shift] {
+		case '}', ']':
+			ix.next = i + 1
+			return int(ix.pos[i]) - ix.shift + 1, true
+		}
+		i++
+	}
+	return 0, false
+}
+
+// seek returns the first entry of the index which is not behind position p of the decoder's buffer.
+func (ix *structuralIndex) seek(p int) int {
+	for ix.next < len(ix.pos) && int(ix.pos[ix.next])-ix.shift < p {
+		ix.next++
+	}
+	return ix.next
+}
+
+// at returns the position in the decoder's buffer of the i-th entry, which must not be behind the cursor.
+func (ix *structuralIndex) at(i int) int {
+	return int(ix.pos[i]) - ix.shift
+}
+
+// indexedKey reads the next key of the object being decoded using the structural index:
+// separators are found from the index, and the key and the colon from the position of the matching quote,
+// without scanning the bytes in between.
+//
+// It returns done if the end of the object is reached. If ok is false, the key can't be read
+// from the index (the key holds escaped characters or the object is malformed), the cursor is then
+// left before the key and the key must be read by scanning the buffer.
+func (dec *Decoder) indexedKey() (key []byte, done bool, ok bool) {
+	ix := dec.index
+	for i := ix.seek(dec.cursor); i < len(ix.pos); i++ {
+		p := ix.at(i)
+		if firstNonBlank(dec.data[dec.cursor:p]) >= 0 {
+			return nil, false, false
+		}
+		switch dec.data[p] {
+		case ',':
+			dec.cursor = p + 1
+			continue
+		case '}':
+			dec.cursor = p + 1
+			ix.next = i + 1
+			return nil, true, true
+		case '"':
+			m := int(ix.match[i])
+			if m+1 >= len(ix.pos) {
+				return nil, false, false
+			}
+			q, c := ix.at(m), ix.at(m+1)
+			key = dec.data[p+1 : q]
+			// escaped keys are unescaped by getString
+			if dec.data[c] != ':' || firstNonBlank(dec.data[q+1:c]) >= 0 || bytes.IndexByte(key, '\\') >= 0 {
+				return nil, false, false
+			}
+			dec.cursor = c + 1
+			ix.next = m + 2
+			return key, false, true
+		}
+		return nil, false, false
+	}
+	return nil, false, false
+}
+
+// indexedSeparator moves the cursor after the next element separator or closing bracket
+// of the array being decoded, found using the structural index, and returns it.
+// It returns 0 and leaves the cursor untouched if the next structural character
+// is not preceded only by white spaces, like when the next element is a number.
+func (dec *Decoder) indexedSeparator() byte {
+	ix := dec.index
+	i := ix.seek(dec.cursor)
+	if i == len(ix.pos) {
+		return 0
+	}
+	p := ix.at(i)
+	c := dec.data[p]
+	if (c != ',' && c != ']') || firstNonBlank(dec.data[dec.cursor:p]) >= 0 {
+		return 0
+	}
+	dec.cursor = p + 1
+	ix.next = i + 1
+	return c
+}
@@ -0,0 +1,219 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testIndexedParent struct {
+	partial *jsonDecodePartial
+	after   string
+	n       int
+}
+
+func (t *testIndexedParent) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "partial":
+		t.partial = &jsonDecodePartial{}
+		return dec.AddObject(t.partial)
+	case "after":
+		return dec.AddString(&t.after)
+	case "n":
+		return dec.AddInt(&t.n)
+	}
+	return nil
+}
+
+func (t *testIndexedParent) NKeys() int {
+	return 0
+}
+
+func TestIndexedDecoderObject(t *testing.T) {
+	t.Run("same-as-regular-decoding", func(t *testing.T) {
+		expected := jsonObjectComplex{}
+		expectedErr := UnmarshalObject(jsonComplex, &expected)
+
+		dec, err := NewIndexedDecoder(jsonComplex)
+		assert.Nil(t, err, "err should be nil")
+		result := jsonObjectComplex{}
+		_, err = dec.decodeObject(&result)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, expectedErr, dec.err, "dec.err should be the same as with regular decoding")
+		assert.Equal(t, expected.Test, result.Test, "result.Test is not expected value")
+		assert.Equal(t, expected.Test2, result.Test2, "result.Test2 is not expected value")
+		assert.Equal(t, expected.Test3, result.Test3, "result.Test3 is not expected value")
+		assert.Equal(t, expected.Test4, result.Test4, "result.Test4 is not expected value")
+		assert.Equal(t, *expected.testSub, *result.testSub, "result.testSub is not expected value")
+	})
+	t.Run("skip-subtrees-after-escaped-strings", func(t *testing.T) {
+		json := []byte(`{
+			"skipped": {"a": "\"}]", "b": [1, {"c": "\"["}]},
+			"partial": {
+				"test": "te\"st",
+				"test2": "test2",
+				"testSkip": {"k": "}"},
+				"testArrSkip": ["]", "\"]"]
+			},
+			"skipStr": "str \"{[",
+			"after": "after\"quote",
+			"skipArr": [[], ["\"", {}]],
+			"n": 12
+		}`)
+		dec, err := NewIndexedDecoder(json)
+		assert.Nil(t, err, "err should be nil")
+		v := &testIndexedParent{}
+		err = dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `te"st`, v.partial.Test, "v.partial.Test is not expected value")
+		assert.Equal(t, "test2", v.partial.Test2, "v.partial.Test2 is not expected value")
+		assert.Equal(t, `after"quote`, v.after, "v.after is not expected value")
+		assert.Equal(t, 12, v.n, "v.n is not expected value")
+	})
+	t.Run("decode-array", func(t *testing.T) {
+		json := []byte(`[
+			{"test": 245, "test2": -246, "test3": "string", "testSkip": [{"x": "]"}]},
+			{"test": 247, "test2": 248, "test3": "str\"ing"}
+		]`)
+		dec, err := NewIndexedDecoder(json)
+		assert.Nil(t, err, "err should be nil")
+		testArr := testSliceObj{}
+		err = dec.Decode(&testArr)
+		assert.Nil(t, err, "err should be nil")
+		assert.Len(t, testArr, 2, "testArr should be of len 2")
+		assert.Equal(t, -246, testArr[0].test2, "testArr[0].test2 should be -246")
+		assert.Equal(t, 247, testArr[1].test, "testArr[1].test should be 247")
+		assert.Equal(t, `str"ing`, testArr[1].test3, "testArr[1].test3 should be 'str\"ing'")
+	})
+}
+
+func TestIndexedDecoderInvalidJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{
+			name: "unclosed-object",
+			json: `{"test": {"test": 1}`,
+		},
+		{
+			name: "unclosed-string",
+			json: `{"test": "test}`,
+		},
+		{
+			name: "mismatched-brackets",
+			json: `{"test": [1, 2}}`,
+		},
+		{
+			name: "unexpected-closing",
+			json: `{"test": 1}}`,
+		},
+		{
+			name: "anti-slash-outside-string",
+			json: `{"test": \1}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec, err := NewIndexedDecoder([]byte(testCase.json))
+			assert.Nil(t, dec, "dec should be nil")
+			assert.NotNil(t, err, "err should not be nil")
+			assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		})
+	}
+}
+
+func TestBuildStructuralIndex(t *testing.T) {
+	json := []byte(`{"a\"b":[1,{"c":"}"}],"d":"\\"}`)
+	ix, err := buildStructuralIndex(json)
+	assert.Nil(t, err, "err should be nil")
+	chars := make([]byte, len(ix.pos))
+	for i, p := range ix.pos {
+		chars[i] = json[p]
+	}
+	assert.Equal(t, `{"":[,{"":""}],"":""}`, string(chars), "index should hold structural chars only")
+	// the opening bracket matches the last char
+	assert.Equal(t, uint32(len(ix.pos)-1), ix.match[0], "first bracket should match the last one")
+	// the array matches its closing bracket
+	assert.Equal(t, byte(']'), json[ix.pos[ix.match[4]]], "array should match its closing bracket")
+}
+
+type testIndexedDoc struct {
+	keys []string
+	n    int
+	s    string
+	objs testSliceObj
+	strs testSliceStrings
+}
+
+func (t *testIndexedDoc) UnmarshalObject(dec *Decoder, key string) error {
+	t.keys = append(t.keys, key)
+	switch key {
+	case "n":
+		return dec.AddInt(&t.n)
+	case "s", `s"x`:
+		return dec.AddString(&t.s)
+	case "objs":
+		return dec.AddArray(&t.objs)
+	case "strs":
+		return dec.AddArray(&t.strs)
+	}
+	return nil
+}
+
+func (t *testIndexedDoc) NKeys() int {
+	return 0
+}
+
+func TestIndexedDecoderDispatch(t *testing.T) {
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{name: "basic", json: `{"n":1,"s":"x","objs":[{"test":1},{"test":2}],"strs":["a","b"]}`},
+		{name: "white-spaces", json: "{ \"n\" :\t1 ,\n\"objs\" : [ {\"test\" : 1} ,\n {\"test\":2} ] , \"strs\" : [ \"a\" , \"b\" ] }"},
+		{name: "escaped-key", json: `{"s\"x": "a\"b", "n": 2, "strs": ["\"", "]"]}`},
+		{name: "empty-containers", json: `{"objs": [], "strs": [ ], "n": 3}`},
+		{name: "unknown-keys", json: `{"skip": {"a": [1, 2]}, "n": 4, "skip2": [{"b": "}"}], "s": "y"}`},
+		{name: "leading-comma", json: `{, "n": 5}`},
+		{name: "missing-commas", json: `{"strs": ["a" "b"] "n": 6}`},
+		{name: "missing-colon", json: `{"n" 7, "s": "z"}`},
+		{name: "null-element", json: `{"objs": [null, {"test": 1}]}`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expected := &testIndexedDoc{}
+			expectedErr := UnmarshalObject([]byte(testCase.json), expected)
+
+			dec, err := NewIndexedDecoder([]byte(testCase.json))
+			assert.Nil(t, err, "err should be nil")
+			v := &testIndexedDoc{}
+			err = dec.DecodeObject(v)
+			if err == nil {
+				err = dec.err
+			}
+			assert.Equal(t, expectedErr, err, "err should be the same as with regular decoding")
+			assert.Equal(t, expected, v, "v should be the same as with regular decoding")
+		})
+	}
+	t.Run("walks-index", func(t *testing.T) {
+		dec, err := NewIndexedDecoder([]byte(testCases[0].json))
+		assert.Nil(t, err, "err should be nil")
+		err = dec.DecodeObject(&testIndexedDoc{})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, len(dec.index.pos), dec.index.next, "all the index should have been walked")
+	})
+	t.Run("indexed-key", func(t *testing.T) {
+		dec, err := NewIndexedDecoder([]byte(`{ "a" : 1, "b\"": 2}`))
+		assert.Nil(t, err, "err should be nil")
+		dec.cursor = 1
+		k, done, ok := dec.indexedKey()
+		assert.True(t, ok, "ok should be true")
+		assert.False(t, done, "done should be false")
+		assert.Equal(t, "a", string(k), "k is not expected value")
+		assert.Equal(t, 7, dec.cursor, "cursor should be after the colon")
+		dec.cursor = 10
+		_, _, ok = dec.indexedKey()
+		assert.False(t, ok, "escaped keys should not be read from the index")
+	})
+}
@@ -0,0 +1,53 @@
+package gojay
+
+import "encoding/json"
+
+// AddJSONUnmarshaler decodes the next key to v using its UnmarshalJSON method.
+// The raw JSON value, including null, is passed to UnmarshalJSON.
+// If UnmarshalJSON returns an error, it is reported according to the decoder's error policy.
+func (dec *Decoder) AddJSONUnmarshaler(v json.Unmarshaler) error {
+	err := dec.decodeJSONUnmarshaler(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+func (dec *Decoder) decodeJSONUnmarshaler(v json.Unmarshaler) error {
+	var raw EmbeddedJSON
+	err := dec.decodeEmbeddedJSON(&raw)
+	if err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		return InvalidJSONError("Invalid JSON")
+	}
+	err = v.UnmarshalJSON(raw)
+	if err != nil {
+		cursor := dec.cursor
+		dec.cursor -= len(raw)
+		dec.recordMismatch(err)
+		dec.cursor = cursor
+		if dec.failed() {
+			return dec.err
+		}
+	}
+	return nil
+}
+
+// JSONUnmarshaler returns a json.Unmarshaler decoding to v with gojay.
+// It allows using gojay for types passed to libraries relying on encoding/json.
+//
+//	json.NewDecoder(r).Decode(gojay.JSONUnmarshaler(user))
+func JSONUnmarshaler(v UnmarshalerObject) json.Unmarshaler {
+	return &jsonUnmarshaler{v}
+}
+
+type jsonUnmarshaler struct {
+	v UnmarshalerObject
+}
+
+func (u *jsonUnmarshaler) UnmarshalJSON(data []byte) error {
+	return UnmarshalObject(data, u.v)
+}
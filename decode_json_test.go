@@ -0,0 +1,120 @@
+package gojay
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJSONValue implements both json.Unmarshaler and encoding.TextUnmarshaler
+type testJSONValue struct {
+	raw  string
+	text bool
+}
+
+func (v *testJSONValue) UnmarshalJSON(b []byte) error {
+	if string(b) == `"invalid"` {
+		return errors.New("invalid value")
+	}
+	v.raw = string(b)
+	return nil
+}
+
+func (v *testJSONValue) UnmarshalText(b []byte) error {
+	v.raw = string(b)
+	v.text = true
+	return nil
+}
+
+type testJSONObj struct {
+	a testJSONValue
+	b json.RawMessage
+	n int
+}
+
+func (o *testJSONObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "a":
+		return dec.AddJSONUnmarshaler(&o.a)
+	case "b":
+		return dec.AddJSONUnmarshaler(&o.b)
+	case "n":
+		return dec.AddInt(&o.n)
+	}
+	return nil
+}
+
+func (o *testJSONObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderJSONUnmarshaler(t *testing.T) {
+	testCases := []struct {
+		name        string
+		json        string
+		expectedRaw string
+	}{
+		{name: "object", json: `{"a":{"b":[1,2]},"n":1}`, expectedRaw: `{"b":[1,2]}`},
+		{name: "array", json: `{"a":[1, "x"],"n":1}`, expectedRaw: `[1, "x"]`},
+		{name: "string", json: `{"a": "str","n":1}`, expectedRaw: `"str"`},
+		{name: "number", json: `{"a":5,"n":1}`, expectedRaw: `5`},
+		{name: "bool", json: `{"a":true,"n":1}`, expectedRaw: `true`},
+		{name: "null", json: `{"a":null,"n":1}`, expectedRaw: `null`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := &testJSONObj{}
+			err := UnmarshalObject([]byte(testCase.json), v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expectedRaw, v.a.raw, "v.a.raw is not expected value")
+			assert.Equal(t, 1, v.n, "v.n is not expected value")
+		})
+	}
+	t.Run("raw-message", func(t *testing.T) {
+		v := &testJSONObj{}
+		err := UnmarshalObject([]byte(`{"b":{"c":1},"n":1}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"c":1}`, string(v.b), "v.b is not expected value")
+	})
+	t.Run("unmarshal-json-error", func(t *testing.T) {
+		v := &testJSONObj{}
+		dec := NewDecoder(strings.NewReader(`{"a":"invalid","n":1}`))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$.a", err.(MismatchError).Path, "err.Path is not expected value")
+		assert.Equal(t, 5, err.(MismatchError).Pos, "err.Pos is not expected value")
+		assert.Equal(t, 0, v.n, "decoding should have stopped before n")
+	})
+	t.Run("decode-api", func(t *testing.T) {
+		v := &testJSONValue{}
+		dec := NewDecoder(strings.NewReader(` "str"`))
+		err := dec.Decode(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"str"`, v.raw, "json.Unmarshaler should take precedence")
+		assert.False(t, v.text, "UnmarshalText should not be called")
+	})
+	t.Run("unmarshal-api", func(t *testing.T) {
+		var raw json.RawMessage
+		err := Unmarshal([]byte(`[1,2]`), &raw)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[1,2]`, string(raw), "raw is not expected value")
+		raw = nil
+		err = Unsafe.Unmarshal([]byte(`{}`), &raw)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{}`, string(raw), "raw is not expected value")
+	})
+}
+
+func TestJSONUnmarshaler(t *testing.T) {
+	v := &TestObj{}
+	err := json.Unmarshal([]byte(`{"test": 1, "test3": "str"}`), JSONUnmarshaler(v))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, v.test, "v.test is not expected value")
+	assert.Equal(t, "str", v.test3, "v.test3 is not expected value")
+	err = json.Unmarshal([]byte(`{"test": "str"}`), JSONUnmarshaler(v))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
@@ -0,0 +1,297 @@
+package gojay
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SetLenient enables or disables the lenient mode of the decoder.
+//
+// In lenient mode the decoder accepts JSONC and a subset of JSON5:
+//   - line (//) and block (/* */) comments
+//   - trailing commas in objects and arrays
+//   - single quoted strings
+//   - unquoted identifier keys
+//   - hexadecimal integers, numbers with a leading + and numbers starting with a dot (.5)
+//
+// The whole input is buffered in memory on first read, until the reader returns io.EOF,
+// and rewritten as strict JSON before being decoded. It should therefore be used for small
+// documents like configuration files, not for streams which never end like a connection
+// carrying several documents. It must be set before decoding starts.
+//
+// Lenient mode only applies to input read from an io.Reader, along with the strings decoded by
+// AddObjectFromString and AddArrayFromString. It has no effect on decoders working on
+// an in-memory buffer like IndexedDecoder, the Unmarshal functions always expect strict JSON.
+func (dec *Decoder) SetLenient(b bool) {
+	dec.lenient = b
+}
+
+// readLenient buffers the whole input until io.EOF, then rewrites it as strict JSON.
+// It is not incremental, the input is only decoded once the reader is exhausted.
+func (dec *Decoder) readLenient() bool {
+	if dec.lenientRead {
+		return false
+	}
+	dec.lenientRead = true
+	src := make([]byte, 0, 512)
+	for {
+		if dec.ctx != nil {
+			if err := dec.ctx.Err(); err != nil {
+				dec.err = err
+				return false
+			}
+			dec.watchContext()
+		}
+		if len(src) == cap(src) {
+			src = append(src, 0)[:len(src)]
+		}
+		n, err := dec.r.Read(src[len(src):cap(src)])
+		src = src[:len(src)+n]
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			dec.err = err
+			return false
+		}
+	}
+	out, err := normalizeLenient(src)
+	if err != nil {
+		dec.err = err
+		return false
+	}
+	if len(out) == 0 {
+		return false
+	}
+	dec.data = append(dec.data[:dec.length], out...)
+	dec.length = len(dec.data)
+	return true
+}
+
+// normalizeLenient rewrites the lenient JSON src as strict JSON.
+func normalizeLenient(src []byte) ([]byte, error) {
+	out := make([]byte, 0, len(src)+len(src)>>4)
+	l := len(src)
+	for i := 0; i < l; {
+		c := src[i]
+		switch {
+		case c == '"':
+			end, err := lenientStringEnd(src, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			out = appendDoubleQuoted(out, src[i+1:end-1])
+			i = end
+		case c == '\'':
+			end, err := lenientStringEnd(src, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			out = appendSingleQuoted(out, src[i+1:end-1])
+			i = end
+		case c == '/':
+			end, err := lenientCommentEnd(src, i)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ' ')
+			i = end
+		case c == ',':
+			// a comma must follow a value, reject leading and consecutive commas
+			j := lastNonBlank(out)
+			if j < 0 || out[j] == '[' || out[j] == '{' || out[j] == ',' || out[j] == ':' {
+				return nil, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+			}
+			out = append(out, c)
+			i++
+		case c == '}' || c == ']':
+			// drop trailing comma
+			if j := lastNonBlank(out); j >= 0 && out[j] == ',' {
+				out[j] = ' '
+			}
+			out = append(out, c)
+			i++
+		case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			var err error
+			out, i, err = appendLenientNumber(out, src, i)
+			if err != nil {
+				return nil, err
+			}
+		case isIdentStart(c):
+			j := i + 1
+			for j < l && (isIdentStart(src[j]) || (src[j] >= '0' && src[j] <= '9')) {
+				j++
+			}
+			// an identifier followed by a colon is a key
+			next, err := lenientSkipSpace(src, j)
+			if err != nil {
+				return nil, err
+			}
+			if next < l && src[next] == ':' {
+				out = append(out, '"')
+				out = append(out, src[i:j]...)
+				out = append(out, '"')
+			} else {
+				out = append(out, src[i:j]...)
+			}
+			i = j
+		default:
+			out = append(out, c)
+			i++
+		}
+	}
+	return out, nil
+}
+
+// lastNonBlank returns the position of the last char of out which is not a white space,
+// or -1 if there is none.
+func lastNonBlank(out []byte) int {
+	j := len(out) - 1
+	for j >= 0 && (out[j] == ' ' || out[j] == '\n' || out[j] == '\t' || out[j] == '\r') {
+		j--
+	}
+	return j
+}
+
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '$'
+}
+
+// lenientStringEnd returns the position right after the closing quote
+// of the string starting at i.
+func lenientStringEnd(src []byte, i int, quote byte) (int, error) {
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, InvalidJSONError(fmt.Sprintf("Invalid JSON, unterminated string starting at position %d", i))
+}
+
+// appendSingleQuoted appends the content of a single quoted string as a double quoted string.
+func appendSingleQuoted(out, s []byte) []byte {
+	out = append(out, '"')
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if j+1 < len(s) && s[j+1] == '\'' {
+				out = append(out, '\'')
+			} else if j+1 < len(s) {
+				out = append(out, s[j], s[j+1])
+			}
+			j++
+		case '"':
+			out = append(out, '\\', '"')
+		default:
+			out = append(out, s[j])
+		}
+	}
+	return append(out, '"')
+}
+
+// appendDoubleQuoted appends the content of a double quoted string as is,
+// except for escaped single quotes which are not valid in strict JSON.
+func appendDoubleQuoted(out, s []byte) []byte {
+	out = append(out, '"')
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if j+1 < len(s) && s[j+1] == '\'' {
+				out = append(out, '\'')
+			} else if j+1 < len(s) {
+				out = append(out, s[j], s[j+1])
+			}
+			j++
+		default:
+			out = append(out, s[j])
+		}
+	}
+	return append(out, '"')
+}
+
+// lenientCommentEnd returns the position right after the comment starting at i.
+func lenientCommentEnd(src []byte, i int) (int, error) {
+	if i+1 < len(src) {
+		switch src[i+1] {
+		case '/':
+			j := i + 2
+			for j < len(src) && src[j] != '\n' {
+				j++
+			}
+			return j, nil
+		case '*':
+			for j := i + 2; j+1 < len(src); j++ {
+				if src[j] == '*' && src[j+1] == '/' {
+					return j + 2, nil
+				}
+			}
+			return 0, InvalidJSONError(fmt.Sprintf("Invalid JSON, unterminated comment starting at position %d", i))
+		}
+	}
+	return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, src[i], i))
+}
+
+// lenientSkipSpace returns the position of the first char after i which is neither
+// a white space nor part of a comment.
+func lenientSkipSpace(src []byte, i int) (int, error) {
+	for i < len(src) {
+		switch src[i] {
+		case ' ', '\n', '\t', '\r':
+			i++
+		case '/':
+			end, err := lenientCommentEnd(src, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+// appendLenientNumber appends the number starting at i as a JSON number
+// and returns the position right after it.
+func appendLenientNumber(out, src []byte, i int) ([]byte, int, error) {
+	l := len(src)
+	switch src[i] {
+	case '+':
+		i++
+	case '-':
+		out = append(out, '-')
+		i++
+	}
+	// hexadecimal integer
+	if i+1 < l && src[i] == '0' && (src[i+1] == 'x' || src[i+1] == 'X') {
+		j := i + 2
+		for j < l && isHexDigit(src[j]) {
+			j++
+		}
+		v, err := strconv.ParseUint(string(src[i+2:j]), 16, 64)
+		if err != nil {
+			return nil, 0, InvalidJSONError(fmt.Sprintf("Invalid JSON, invalid hexadecimal number at position %d", i))
+		}
+		return strconv.AppendUint(out, v, 10), j, nil
+	}
+	if i < l && src[i] == '.' {
+		out = append(out, '0')
+	}
+	j := i
+	for j < l {
+		switch src[j] {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '.', 'e', 'E', '+', '-':
+			j++
+			continue
+		}
+		break
+	}
+	return append(out, src[i:j]...), j, nil
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,204 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderLenient(t *testing.T) {
+	t.Run("config", func(t *testing.T) {
+		json := `// service configuration
+		{
+			/* block
+			   comment */
+			test: 0x1F, // hexadecimal
+			test2: +12,
+			'test3': 'it\'s "quoted"',
+			test4: "double // not a comment",
+			test5: .5,
+			testSubObj: {test: -0x10, test2: 2,},
+			testArr: [
+				{test: 1},
+				{test: 2}, // trailing comma
+			],
+		}`
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(json))
+		dec.SetLenient(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Nil(t, dec.err, "dec.err should be nil")
+		assert.Equal(t, 31, v.test, "v.test should be 31")
+		assert.Equal(t, 12, v.test2, "v.test2 should be 12")
+		assert.Equal(t, `it's "quoted"`, v.test3, "v.test3 is not expected value")
+		assert.Equal(t, "double // not a comment", v.test4, "v.test4 is not expected value")
+		assert.Equal(t, 0.5, v.test5, "v.test5 should be 0.5")
+		assert.Equal(t, -16, v.testSubObj.test3, "v.testSubObj.test3 should be -16")
+		assert.Equal(t, 2, v.testSubObj.test4, "v.testSubObj.test4 should be 2")
+		assert.Len(t, v.testArr, 2, "v.testArr should be of len 2")
+		assert.Equal(t, 2, v.testArr[1].test, "v.testArr[1].test should be 2")
+	})
+	t.Run("escaped-single-quote", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{test3: "it\'s"}`))
+		dec.SetLenient(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "it's", v.test3, "v.test3 is not expected value")
+	})
+	t.Run("leading-comma", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{, test: 1}`))
+		dec.SetLenient(true)
+		err := dec.DecodeObject(v)
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+	t.Run("strict-by-default", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{test: 1}`))
+		dec.DecodeObject(v)
+		assert.Equal(t, 0, v.test, "unquoted keys should not be decoded in strict mode")
+	})
+	t.Run("pooled-decoder-reset", func(t *testing.T) {
+		dec := BorrowDecoder(strings.NewReader(`{"test": 1}`))
+		dec.SetLenient(true)
+		dec.Release()
+		dec = BorrowDecoder(strings.NewReader(`{"test": 1}`))
+		defer dec.Release()
+		assert.False(t, dec.lenient, "lenient mode should be reset")
+		assert.False(t, dec.lenientRead, "lenient state should be reset")
+	})
+	t.Run("read-once", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`1 /* a */ 2`))
+		dec.SetLenient(true)
+		var a, b int
+		assert.Nil(t, dec.DecodeInt(&a), "err should be nil")
+		assert.Nil(t, dec.DecodeInt(&b), "err should be nil")
+		assert.True(t, dec.lenientRead, "input should be read")
+		assert.Equal(t, 1, a, "a should be 1")
+		assert.Equal(t, 2, b, "b should be 2")
+	})
+	t.Run("in-memory-buffer", func(t *testing.T) {
+		v := &TestObj{}
+		dec, err := NewIndexedDecoder([]byte(`{test: 1}`))
+		assert.Nil(t, err, "err should be nil")
+		dec.SetLenient(true)
+		dec.DecodeObject(v)
+		assert.Equal(t, 0, v.test, "lenient mode should have no effect without a reader")
+	})
+}
+
+func TestNormalizeLenient(t *testing.T) {
+	testCases := []struct {
+		name         string
+		json         string
+		expectedJSON string
+		err          bool
+	}{
+		{
+			name:         "line-comment",
+			json:         "[1, // one\n2]",
+			expectedJSON: "[1,  \n2]",
+		},
+		{
+			name:         "block-comment",
+			json:         `[1, /* one, two */ 2]`,
+			expectedJSON: `[1,   2]`,
+		},
+		{
+			name:         "trailing-commas",
+			json:         `{"a": [1, 2, ], "b": {"c": 1,}, }`,
+			expectedJSON: `{"a": [1, 2  ], "b": {"c": 1 }  }`,
+		},
+		{
+			name:         "single-quotes",
+			json:         `['a"b', 'c\'d', 'e\"f']`,
+			expectedJSON: `["a\"b", "c'd", "e\"f"]`,
+		},
+		{
+			name:         "escaped-single-quote-in-double-quotes",
+			json:         `["it\'s", "a\\'", "b\"\n"]`,
+			expectedJSON: `["it's", "a\\'", "b\"\n"]`,
+		},
+		{
+			name:         "unquoted-keys",
+			json:         `{a: true, $b_1 /* comment */ : null, c: false}`,
+			expectedJSON: `{"a": true, "$b_1"   : null, "c": false}`,
+		},
+		{
+			name:         "numbers",
+			json:         `[0xff, -0XFF, +1, +.5, -.5e2, 1e+2]`,
+			expectedJSON: `[255, -255, 1, 0.5, -0.5e2, 1e+2]`,
+		},
+		{
+			name:         "comment-like-in-string",
+			json:         `["/* a */", "// b"]`,
+			expectedJSON: `["/* a */", "// b"]`,
+		},
+		{
+			name: "unterminated-comment",
+			json: `[1 /* one`,
+			err:  true,
+		},
+		{
+			name: "unterminated-string",
+			json: `['one]`,
+			err:  true,
+		},
+		{
+			name: "invalid-hex",
+			json: `[0x]`,
+			err:  true,
+		},
+		{
+			name: "invalid-slash",
+			json: `[1 / 2]`,
+			err:  true,
+		},
+		{
+			name: "only-comma-array",
+			json: `[,]`,
+			err:  true,
+		},
+		{
+			name: "only-comma-object",
+			json: `{ , }`,
+			err:  true,
+		},
+		{
+			name: "leading-comma",
+			json: `[, 1]`,
+			err:  true,
+		},
+		{
+			name: "leading-comma-after-comment",
+			json: `[/* a */, 1]`,
+			err:  true,
+		},
+		{
+			name: "consecutive-commas",
+			json: `[1,, 2]`,
+			err:  true,
+		},
+		{
+			name: "comma-after-colon",
+			json: `{"a": , }`,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			out, err := normalizeLenient([]byte(testCase.json))
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expectedJSON, string(out), "out is not expected value")
+		})
+	}
+}
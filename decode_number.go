@@ -2,6 +2,8 @@ package gojay
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 )
 
 var digits []int8
@@ -60,7 +62,8 @@ func (dec *Decoder) DecodeInt(v *int) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeInt(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeInt(v))
 }
 func (dec *Decoder) decodeInt(v *int) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -92,14 +95,23 @@ func (dec *Decoder) decodeInt(v *int) error {
 			dec.cursor++
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedInt(strconv.IntSize)
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = int(val)
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to int, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -116,7 +128,8 @@ func (dec *Decoder) DecodeInt32(v *int32) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeInt32(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeInt32(v))
 }
 func (dec *Decoder) decodeInt32(v *int32) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -146,14 +159,23 @@ func (dec *Decoder) decodeInt32(v *int32) error {
 			}
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedInt(32)
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = int32(val)
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to int, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -170,7 +192,8 @@ func (dec *Decoder) DecodeUint32(v *uint32) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeUint32(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeUint32(v))
 }
 
 func (dec *Decoder) decodeUint32(v *uint32) error {
@@ -202,14 +225,23 @@ func (dec *Decoder) decodeUint32(v *uint32) error {
 			}
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedUint(32)
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = uint32(val)
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to int, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -226,7 +258,8 @@ func (dec *Decoder) DecodeInt64(v *int64) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeInt64(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeInt64(v))
 }
 
 func (dec *Decoder) decodeInt64(v *int64) error {
@@ -257,14 +290,23 @@ func (dec *Decoder) decodeInt64(v *int64) error {
 			}
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedInt(64)
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = val
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to int, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -281,7 +323,8 @@ func (dec *Decoder) DecodeUint64(v *uint64) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeUint64(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeUint64(v))
 }
 func (dec *Decoder) decodeUint64(v *uint64) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -312,14 +355,23 @@ func (dec *Decoder) decodeUint64(v *uint64) error {
 			}
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedUint(64)
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = val
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to int, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -336,7 +388,8 @@ func (dec *Decoder) DecodeFloat64(v *float64) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeFloat64(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeFloat64(v))
 }
 func (dec *Decoder) decodeFloat64(v *float64) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -352,6 +405,14 @@ func (dec *Decoder) decodeFloat64(v *float64) error {
 			return nil
 		case '-':
 			dec.cursor = dec.cursor + 1
+			if dec.allowNonFinite && (dec.cursor < dec.length || dec.read()) && dec.data[dec.cursor] == 'I' {
+				val, err := dec.getNonFinite()
+				if err != nil {
+					return err
+				}
+				*v = -val
+				return nil
+			}
 			val, err := dec.getFloat(c)
 			if err != nil {
 				return err
@@ -365,15 +426,44 @@ func (dec *Decoder) decodeFloat64(v *float64) error {
 				return err
 			}
 			return nil
+		case 'N', 'I':
+			if dec.allowNonFinite {
+				val, err := dec.getNonFinite()
+				if err != nil {
+					return err
+				}
+				*v = val
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to float, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			))
+			if err != nil {
+				return err
+			}
+			return nil
 		default:
-			dec.err = InvalidTypeError(
+			if c == '"' && dec.quotedValues {
+				val, ok, err := dec.getQuotedFloat()
+				if err != nil {
+					return err
+				}
+				if ok {
+					*v = val
+				}
+				return nil
+			}
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to float, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -383,6 +473,30 @@ func (dec *Decoder) decodeFloat64(v *float64) error {
 	return InvalidJSONError("Invalid JSON while parsing float")
 }
 
+// SetAllowNonFinite enables or disables decoding of the NaN, Infinity and -Infinity literals
+// produced by Python or JavaScript to float64. They are rejected by default as they are not valid JSON.
+func (dec *Decoder) SetAllowNonFinite(b bool) {
+	dec.allowNonFinite = b
+}
+
+// getNonFinite parses the NaN or Infinity literal at the cursor.
+func (dec *Decoder) getNonFinite() (float64, error) {
+	lit, val := "NaN", math.NaN()
+	if dec.data[dec.cursor] == 'I' {
+		lit, val = "Infinity", math.Inf(1)
+	}
+	for i := 0; i < len(lit); i++ {
+		if dec.cursor >= dec.length && !dec.read() {
+			return 0, InvalidJSONError("Invalid JSON while parsing float")
+		}
+		if dec.data[dec.cursor] != lit[i] {
+			return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[dec.cursor], dec.cursor))
+		}
+		dec.cursor++
+	}
+	return val, nil
+}
+
 func (dec *Decoder) skipNumber() (int, error) {
 	end := dec.cursor + 1
 	// look for following numbers
@@ -728,18 +842,18 @@ func (dec *Decoder) atoi64(start, end int) int64 {
 		for i := start + 1; i < end; i++ {
 			intv := int64(digits[dec.data[i]])
 			if val > maxInt64toMultiply {
-				dec.err = InvalidTypeError("Overflows int64")
+				dec.recordMismatch(InvalidTypeError("Overflows int64"))
 				return 0
 			}
 			val = (val << 3) + (val << 1)
 			if maxInt64-val < intv {
-				dec.err = InvalidTypeError("Overflows int64")
+				dec.recordMismatch(InvalidTypeError("Overflows int64"))
 				return 0
 			}
 			val += intv
 		}
 	} else {
-		dec.err = InvalidTypeError("Overflows int64")
+		dec.recordMismatch(InvalidTypeError("Overflows int64"))
 		return 0
 	}
 	return val
@@ -758,18 +872,18 @@ func (dec *Decoder) atoui64(start, end int) uint64 {
 		for i := start + 1; i < end; i++ {
 			uintv := uint64(digits[dec.data[i]])
 			if val > maxUint64toMultiply {
-				dec.err = InvalidTypeError("Overflows uint64")
+				dec.recordMismatch(InvalidTypeError("Overflows uint64"))
 				return 0
 			}
 			val = (val << 3) + (val << 1)
 			if maxUint64-val < uintv {
-				dec.err = InvalidTypeError("Overflows uint64")
+				dec.recordMismatch(InvalidTypeError("Overflows uint64"))
 				return 0
 			}
 			val += uintv
 		}
 	} else {
-		dec.err = InvalidTypeError("Overflows uint64")
+		dec.recordMismatch(InvalidTypeError("Overflows uint64"))
 		return 0
 	}
 	return val
@@ -789,18 +903,18 @@ func (dec *Decoder) atoi32(start, end int) int32 {
 		for i := start + 1; i < end; i++ {
 			intv := int32(digits[dec.data[i]])
 			if val > maxInt32toMultiply {
-				dec.err = InvalidTypeError("Overflows int32")
+				dec.recordMismatch(InvalidTypeError("Overflows int32"))
 				return 0
 			}
 			val = (val << 3) + (val << 1)
 			if maxInt32-val < intv {
-				dec.err = InvalidTypeError("Overflows int32")
+				dec.recordMismatch(InvalidTypeError("Overflows int32"))
 				return 0
 			}
 			val += intv
 		}
 	} else {
-		dec.err = InvalidTypeError("Overflows int32")
+		dec.recordMismatch(InvalidTypeError("Overflows int32"))
 		return 0
 	}
 	return val
@@ -820,18 +934,18 @@ func (dec *Decoder) atoui32(start, end int) uint32 {
 		for i := start + 1; i < end; i++ {
 			uintv := uint32(digits[dec.data[i]])
 			if val > maxUint32toMultiply {
-				dec.err = InvalidTypeError("Overflows uint32")
+				dec.recordMismatch(InvalidTypeError("Overflows uint32"))
 				return 0
 			}
 			val = (val << 3) + (val << 1)
 			if maxUint32-val < uintv {
-				dec.err = InvalidTypeError("Overflows int32")
+				dec.recordMismatch(InvalidTypeError("Overflows int32"))
 				return 0
 			}
 			val += uintv
 		}
 	} else if ll > maxUint32Length {
-		dec.err = InvalidTypeError("Overflows uint32")
+		dec.recordMismatch(InvalidTypeError("Overflows uint32"))
 		val = 0
 	}
 	return val
@@ -1154,3 +1154,53 @@ func TestDecodeNumberExra(t *testing.T) {
 		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
 	})
 }
+
+func TestDecodeNumberNonFinite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected float64
+	}{
+		{name: "nan", json: `NaN`, expected: math.NaN()},
+		{name: "infinity", json: ` Infinity`, expected: math.Inf(1)},
+		{name: "minus-infinity", json: `-Infinity`, expected: math.Inf(-1)},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var v float64
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			dec.SetAllowNonFinite(true)
+			err := dec.DecodeFloat64(&v)
+			assert.Nil(t, err, "err should be nil")
+			if math.IsNaN(testCase.expected) {
+				assert.True(t, math.IsNaN(v), "v should be NaN")
+				return
+			}
+			assert.Equal(t, testCase.expected, v, "v is not expected value")
+		})
+	}
+	t.Run("object", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{"skip": NaN, "skip2": [-Infinity, Infinity], "test5": -Infinity, "test": 1}`))
+		dec.SetAllowNonFinite(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Nil(t, dec.err, "dec.err should be nil")
+		assert.True(t, math.IsInf(v.test5, -1), "v.test5 should be -Infinity")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+	})
+	t.Run("invalid-literal", func(t *testing.T) {
+		var v float64
+		dec := NewDecoder(strings.NewReader(`Infinite`))
+		dec.SetAllowNonFinite(true)
+		err := dec.DecodeFloat64(&v)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+	t.Run("rejected-by-default", func(t *testing.T) {
+		var v float64
+		err := Unmarshal([]byte(`NaN`), &v)
+		assert.NotNil(t, err, "err should not be nil")
+		err = Unmarshal([]byte(`{"test5": Infinity}`), &TestObj{})
+		assert.NotNil(t, err, "err should not be nil")
+	})
+}
@@ -14,9 +14,13 @@ func (dec *Decoder) DecodeObject(j UnmarshalerObject) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
+	dec.resetPolicy()
 	_, err := dec.decodeObject(j)
-	return err
+	return dec.policyErr(err)
 }
+
+// decodeObject keeps its own loops rather than going through decodeObjectKeys,
+// calling UnmarshalObject directly keeps an indirect call per key off the hot path.
 func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 	keys := j.NKeys()
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -24,6 +28,7 @@ func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 		case ' ', '\n', '\t', '\r', ',':
 		case '{':
 			dec.cursor = dec.cursor + 1
+			depth := len(dec.path)
 			// if keys is zero we will parse all keys
 			// we run two loops for micro optimization
 			if keys == 0 {
@@ -34,9 +39,14 @@ func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 					} else if done {
 						return dec.cursor, nil
 					}
+					if dec.tracksPath() {
+						dec.path = append(dec.path[:depth], pathElem{key: string(k), index: -1})
+					}
 					err = j.UnmarshalObject(dec, k)
 					if err != nil {
 						return 0, err
+					} else if dec.failed() {
+						return 0, dec.err
 					} else if dec.called&1 == 0 {
 						err := dec.skipData()
 						if err != nil {
@@ -55,9 +65,14 @@ func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 					} else if done {
 						return dec.cursor, nil
 					}
+					if dec.tracksPath() {
+						dec.path = append(dec.path[:depth], pathElem{key: string(k), index: -1})
+					}
 					err = j.UnmarshalObject(dec, k)
 					if err != nil {
 						return 0, err
+					} else if dec.failed() {
+						return 0, dec.err
 					} else if dec.called&1 == 0 {
 						err := dec.skipData()
 						if err != nil {
@@ -88,14 +103,81 @@ func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 			return dec.cursor, nil
 		default:
 			// can't unmarshall to struct
-			dec.err = InvalidTypeError(
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshal to struct, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
+			if err != nil {
+				return 0, err
+			}
+			return dec.cursor, nil
+		}
+	}
+	return 0, InvalidJSONError("Invalid JSON while parsing object")
+}
+
+// keyDecoder reads the next key of the object being decoded and decodes its value.
+// It returns true if the end of the object is reached instead of a key.
+// depth is the length of the decoder's path for the object.
+type keyDecoder func(depth int) (bool, error)
+
+// decodeObjectKeys decodes the object at the cursor, calling decodeKey for each key.
+// If keys is not zero, decoding stops once keys keys have been decoded.
+func (dec *Decoder) decodeObjectKeys(keys int, decodeKey keyDecoder) (int, error) {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+		case '{':
+			dec.cursor = dec.cursor + 1
+			depth := len(dec.path)
+			// if keys is zero we will parse all keys
+			for (dec.cursor < dec.length || dec.read()) && (keys == 0 || dec.keysDone < keys) {
+				done, err := decodeKey(depth)
+				if err != nil {
+					return 0, err
+				} else if done {
+					return dec.cursor, nil
+				} else if dec.failed() {
+					return 0, dec.err
+				} else if dec.called&1 == 0 {
+					err := dec.skipData()
+					if err != nil {
+						return 0, err
+					}
+				} else {
+					dec.keysDone++
+				}
+				dec.called &= 0
+			}
+			// will get to that point when keysDone is not lower than keys anymore
+			// in that case, we make sure cursor goes to the end of object, but we skip
+			// unmarshalling
+			if dec.child&1 != 0 {
+				end, err := dec.skipObject()
+				dec.cursor = end
+				return dec.cursor, err
+			}
+			return dec.cursor, nil
+		case 'n':
+			dec.cursor++
+			err := dec.assertNull()
+			if err != nil {
+				return 0, err
+			}
+			dec.cursor++
+			return dec.cursor, nil
+		default:
+			// can't unmarshall to struct
+			err := dec.mismatch(InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshal to struct, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			))
 			if err != nil {
 				return 0, err
 			}
@@ -106,6 +188,12 @@ func (dec *Decoder) decodeObject(j UnmarshalerObject) (int, error) {
 }
 
 func (dec *Decoder) skipObject() (int, error) {
+	// with a structural index, jump straight to the closing bracket
+	if dec.index != nil {
+		if end, ok := dec.index.skip(dec.data, dec.cursor); ok {
+			return end, nil
+		}
+	}
 	var objectsOpen = 1
 	var objectsClosed = 0
 	// var stringOpen byte = 0
@@ -151,6 +239,11 @@ func (dec *Decoder) skipObject() (int, error) {
 }
 
 func (dec *Decoder) nextKey() (string, bool, error) {
+	if dec.index != nil {
+		if k, done, ok := dec.indexedKey(); ok {
+			return *(*string)(unsafe.Pointer(&k)), done, nil
+		}
+	}
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
 		switch dec.data[dec.cursor] {
 		case ' ', '\n', '\t', '\r', ',':
@@ -227,7 +320,18 @@ func (dec *Decoder) skipData() error {
 			end, err := dec.skipArray()
 			dec.cursor = end
 			return err
+		case 'N', 'I':
+			if dec.allowNonFinite {
+				_, err := dec.getNonFinite()
+				return err
+			}
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '-':
+			if dec.allowNonFinite && dec.data[dec.cursor] == '-' &&
+				(dec.cursor+1 < dec.length || dec.read()) && dec.data[dec.cursor+1] == 'I' {
+				dec.cursor++
+				_, err := dec.getNonFinite()
+				return err
+			}
 			end, err := dec.skipNumber()
 			dec.cursor = end
 			return err
@@ -0,0 +1,170 @@
+package gojay
+
+import "unsafe"
+
+// UnmarshalerObjectIndex is the interface to implement for a struct to be
+// decoded switching on the index of keys instead of their name.
+//
+// ObjectKeys must return the Keys registry listing the keys of the struct,
+// it should be built once per type and shared between instances.
+// The index given to UnmarshalObjectIndex is the index of the key in the registry,
+// unknown keys are skipped.
+type UnmarshalerObjectIndex interface {
+	UnmarshalObjectIndex(*Decoder, int) error
+	ObjectKeys() *Keys
+	NKeys() int
+}
+
+// Keys is a registry of object keys used to decode an UnmarshalerObjectIndex.
+// Keys found while decoding are hashed directly from the decoder's buffer
+// and looked up in the registry to get their index.
+//
+// It is safe for concurrent use.
+type Keys struct {
+	names []string
+	// slots is an open addressing hash table holding index+1 of names, 0 is an empty slot
+	slots []int32
+	mask  uint32
+}
+
+// NewKeys returns a Keys registry for the given key names.
+// The index of a key is its position in names.
+//
+//	var userKeys = gojay.NewKeys("id", "name", "email")
+func NewKeys(names ...string) *Keys {
+	size := 2
+	for size < len(names)<<1 {
+		size <<= 1
+	}
+	k := &Keys{
+		names: names,
+		slots: make([]int32, size),
+		mask:  uint32(size - 1),
+	}
+	for i, name := range names {
+		h := hashKey(name) & k.mask
+		for k.slots[h] != 0 {
+			h = (h + 1) & k.mask
+		}
+		k.slots[h] = int32(i + 1)
+	}
+	return k
+}
+
+// Index returns the index of key in the registry or -1 if key is unknown.
+func (k *Keys) Index(key string) int {
+	h := hashKey(key) & k.mask
+	for {
+		s := k.slots[h]
+		if s == 0 {
+			return -1
+		}
+		if k.names[s-1] == key {
+			return int(s - 1)
+		}
+		h = (h + 1) & k.mask
+	}
+}
+
+// Len returns the number of keys in the registry.
+func (k *Keys) Len() int {
+	return len(k.names)
+}
+
+// hashKey is a 32 bits FNV-1a hash
+func hashKey(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
+
+// DecodeObjectIndex reads the next JSON-encoded value from its input and stores it in the value pointed to by v.
+//
+// v must implement UnmarshalerObjectIndex.
+//
+// See the documentation for Unmarshal for details about the conversion of JSON into a Go value.
+func (dec *Decoder) DecodeObjectIndex(v UnmarshalerObjectIndex) error {
+	if dec.isPooled == 1 {
+		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
+	}
+	dec.resetPolicy()
+	_, err := dec.decodeObjectIndex(v)
+	return dec.policyErr(err)
+}
+
+func (dec *Decoder) decodeObjectIndex(j UnmarshalerObjectIndex) (int, error) {
+	registry := j.ObjectKeys()
+	return dec.decodeObjectKeys(j.NKeys(), func(depth int) (bool, error) {
+		k, done, err := dec.nextKeyIndex(registry)
+		// unknown keys are skipped without calling the unmarshaler
+		if err != nil || done || k < 0 {
+			return done, err
+		}
+		if dec.tracksPath() {
+			dec.path = append(dec.path[:depth], pathElem{key: registry.names[k], index: -1})
+		}
+		return false, j.UnmarshalObjectIndex(dec, k)
+	})
+}
+
+// nextKeyIndex reads the next key and returns its index in the registry.
+func (dec *Decoder) nextKeyIndex(registry *Keys) (int, bool, error) {
+	if dec.index != nil {
+		if k, done, ok := dec.indexedKey(); ok {
+			if done {
+				return 0, true, nil
+			}
+			return registry.Index(*(*string)(unsafe.Pointer(&k))), false, nil
+		}
+	}
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '"':
+			dec.cursor = dec.cursor + 1
+			start, end, err := dec.getString()
+			if err != nil {
+				return 0, false, err
+			}
+			var found byte
+			for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+				if dec.data[dec.cursor] == ':' {
+					found |= 1
+					break
+				}
+			}
+			if found&1 != 0 {
+				dec.cursor++
+				d := dec.data[start : end-1]
+				return registry.Index(*(*string)(unsafe.Pointer(&d))), false, nil
+			}
+			return 0, false, InvalidJSONError("Invalid JSON while parsing object key")
+		case '}':
+			dec.cursor = dec.cursor + 1
+			return 0, true, nil
+		}
+	}
+	return 0, false, InvalidJSONError("Invalid JSON while parsing object key")
+}
+
+// AddObjectIndex decodes the next key to a UnmarshalerObjectIndex.
+func (dec *Decoder) AddObjectIndex(value UnmarshalerObjectIndex) error {
+	initialKeysDone := dec.keysDone
+	initialChild := dec.child
+	dec.keysDone = 0
+	dec.called = 0
+	dec.child |= 1
+	newCursor, err := dec.decodeObjectIndex(value)
+	if err != nil {
+		return err
+	}
+	dec.cursor = newCursor
+	dec.keysDone = initialKeysDone
+	dec.child = initialChild
+	dec.called |= 1
+	return nil
+}
@@ -0,0 +1,126 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testObjIndexKeys = NewKeys("test", "test2", "test3", "sub", "arr")
+
+type testObjIndex struct {
+	test  int
+	test2 int
+	test3 string
+	sub   *testObjIndex
+	arr   testSliceStrings
+	calls int
+}
+
+func (t *testObjIndex) UnmarshalObjectIndex(dec *Decoder, k int) error {
+	t.calls++
+	switch k {
+	case 0:
+		return dec.AddInt(&t.test)
+	case 1:
+		return dec.AddInt(&t.test2)
+	case 2:
+		return dec.AddString(&t.test3)
+	case 3:
+		t.sub = &testObjIndex{}
+		return dec.AddObjectIndex(t.sub)
+	case 4:
+		return dec.AddArray(&t.arr)
+	}
+	return nil
+}
+
+func (t *testObjIndex) ObjectKeys() *Keys {
+	return testObjIndexKeys
+}
+
+func (t *testObjIndex) NKeys() int {
+	return 0
+}
+
+func TestKeys(t *testing.T) {
+	names := make([]string, 40)
+	for i := range names {
+		names[i] = "key" + strings.Repeat("x", i)
+	}
+	keys := NewKeys(names...)
+	assert.Equal(t, 40, keys.Len(), "keys.Len() should be 40")
+	for i, name := range names {
+		assert.Equal(t, i, keys.Index(name), "keys.Index should return the position of the key")
+	}
+	assert.Equal(t, -1, keys.Index("unknown"), "unknown key should return -1")
+	assert.Equal(t, -1, keys.Index(""), "unknown key should return -1")
+	assert.Equal(t, -1, NewKeys().Index("test"), "empty registry should return -1")
+}
+
+func TestDecodeObjectIndex(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		json := []byte(`{
+			"test": 245,
+			"unknown": {"test": 1, "arr": [1, 2]},
+			"test2": -246,
+			"te\"st": 1,
+			"test3": "str\"ing",
+			"sub": {"test": 1, "skipped": [], "test3": "sub"},
+			"arr": ["a", "b", "c"]
+		}`)
+		v := &testObjIndex{}
+		err := Unmarshal(json, v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 245, v.test, "v.test should be 245")
+		assert.Equal(t, -246, v.test2, "v.test2 should be -246")
+		assert.Equal(t, `str"ing`, v.test3, "v.test3 is not expected value")
+		assert.Equal(t, 1, v.sub.test, "v.sub.test should be 1")
+		assert.Equal(t, "sub", v.sub.test3, "v.sub.test3 is not expected value")
+		assert.Equal(t, testSliceStrings{"a", "b", "c"}, v.arr, "v.arr is not expected value")
+		assert.Equal(t, 5, v.calls, "unknown keys should not call UnmarshalObjectIndex")
+	})
+	t.Run("decoder", func(t *testing.T) {
+		v := &testObjIndex{}
+		dec := NewDecoder(strings.NewReader(`{"test": 1, "test3": "test"}`))
+		err := dec.DecodeObjectIndex(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+		assert.Equal(t, "test", v.test3, "v.test3 is not expected value")
+	})
+	t.Run("decode-interface", func(t *testing.T) {
+		v := &testObjIndex{}
+		dec := NewDecoder(strings.NewReader(`{"test2": 2}`))
+		err := dec.Decode(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, v.test2, "v.test2 should be 2")
+	})
+	t.Run("unsafe", func(t *testing.T) {
+		v := &testObjIndex{}
+		err := Unsafe.Unmarshal([]byte(`{"test": 3, "sub": null}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 3, v.test, "v.test should be 3")
+	})
+	t.Run("null", func(t *testing.T) {
+		v := &testObjIndex{}
+		err := Unmarshal([]byte(`null`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 0, v.calls, "UnmarshalObjectIndex should not be called")
+	})
+	t.Run("invalid-type", func(t *testing.T) {
+		v := &testObjIndex{}
+		err := Unmarshal([]byte(`{"test": "str"}`), v)
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		err = Unmarshal([]byte(`[]`), v)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		v := &testObjIndex{}
+		err := Unmarshal([]byte(`{"test": 1, "test2"`), v)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		err = Unmarshal([]byte(`{"test`), v)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+}
@@ -506,3 +506,46 @@ func TestSkipData(t *testing.T) {
 		assert.IsType(t, InvalidJSONError(""), err, "err should of type InvalidJSONError")
 	})
 }
+
+var benchDecodeObjectJSON = []byte(`{
+	"test": 245,
+	"test2": -246,
+	"test3": "string",
+	"test4": "string with \"escaped\" quotes",
+	"test5": -1.5,
+	"testArr": [{"test": 1, "test3": "a"}, {"test": 2, "test3": "b"}],
+	"testSubObj": {"test": 3, "test2": 4, "test3": "sub", "testSubSubObj": {"test": 5}},
+	"unknown": {"a": [1, 2, 3], "b": "skipped"}
+}`)
+
+var benchDecodeWideObjectJSON = []byte(`{"a":1,"b":2,"c":3,"d":4,"e":5,"f":6,"g":7,"h":8,"i":9,"j":10,` +
+	`"k":11,"l":12,"m":13,"n":14,"o":15,"p":16,"q":17,"r":18,"s":19,"t":20}`)
+
+func BenchmarkDecodeObject(b *testing.B) {
+	b.Run("nested", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(benchDecodeObjectJSON)))
+		for i := 0; i < b.N; i++ {
+			v := &TestObj{}
+			if err := UnmarshalObject(benchDecodeObjectJSON, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("wide", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(benchDecodeWideObjectJSON)))
+		var sum int
+		v := DecodeObjectFunc(func(dec *Decoder, k string) error {
+			var n int
+			err := dec.AddInt(&n)
+			sum += n
+			return err
+		})
+		for i := 0; i < b.N; i++ {
+			if err := UnmarshalObject(benchDecodeWideObjectJSON, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
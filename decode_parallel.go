@@ -0,0 +1,372 @@
+package gojay
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DecodeArrayParallel decodes a top level JSON array of objects using workers go routines.
+// It is a shortcut for NewParallelArrayDecoder(workers, newElem).Decode(data).
+//
+// newElem is called for each element of the array with its index
+// and must return the UnmarshalerObject to decode the element to.
+// It is called concurrently by the workers.
+func DecodeArrayParallel(data []byte, workers int, newElem func(i int) UnmarshalerObject) error {
+	return NewParallelArrayDecoder(workers, newElem).Decode(data)
+}
+
+// ParallelArrayDecoder decodes the elements of a large top level JSON array concurrently.
+//
+// The array is split at element boundaries found by a structural scan of the document
+// (see IndexedDecoder), then chunks of elements are decoded by workers using pooled Decoders.
+type ParallelArrayDecoder struct {
+	workers   int
+	ordered   bool
+	newElem   func(i int) UnmarshalerObject
+	onElement func(i int, v UnmarshalerObject)
+}
+
+// NewParallelArrayDecoder returns a new ParallelArrayDecoder using workers go routines.
+// If workers is lower than 1, runtime.NumCPU() workers are used.
+//
+// newElem is called for each element of the array with its index
+// and must return the UnmarshalerObject to decode the element to.
+// It is called concurrently by the workers.
+func NewParallelArrayDecoder(workers int, newElem func(i int) UnmarshalerObject) *ParallelArrayDecoder {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	return &ParallelArrayDecoder{
+		workers: workers,
+		newElem: newElem,
+	}
+}
+
+// OnElement sets a func called each time an element has been successfully decoded.
+//
+// f is always called from the go routine calling Decode,
+// by default in the order elements are decoded, see Ordered.
+func (p *ParallelArrayDecoder) OnElement(f func(i int, v UnmarshalerObject)) *ParallelArrayDecoder {
+	p.onElement = f
+	return p
+}
+
+// Ordered makes the func set with OnElement receive elements in the order of the array.
+//
+// To bound memory, workers don't decode elements too far ahead of the next one to deliver,
+// a slow element therefore slows down decoding of the following ones.
+func (p *ParallelArrayDecoder) Ordered() *ParallelArrayDecoder {
+	p.ordered = true
+	return p
+}
+
+// Unordered makes the func set with OnElement receive elements as soon as they are decoded.
+// It is the default.
+func (p *ParallelArrayDecoder) Unordered() *ParallelArrayDecoder {
+	p.ordered = false
+	return p
+}
+
+type parallelElement struct {
+	i int
+	v UnmarshalerObject
+}
+
+const (
+	// parallelMaxChunkSize is the maximum number of elements decoded by a worker in a row
+	parallelMaxChunkSize = 256
+	// parallelWindowChunks is the number of chunks per worker which can be decoded
+	// ahead of the next element to deliver when elements are delivered in order
+	parallelWindowChunks = 2
+)
+
+// Decode decodes the JSON array data.
+//
+// Decoding stops at the first element which fails to decode, in that case
+// an ArrayElementError holding the lowest failing index is returned.
+// Elements before it are all decoded and delivered, elements after it may not be.
+//
+// null elements leave the value returned by newElem untouched, like AddObject does.
+func (p *ParallelArrayDecoder) Decode(data []byte) error {
+	bounds, err := arrayElementBounds(data)
+	if err != nil || len(bounds) == 0 {
+		return err
+	}
+	// decoding modifies the buffer while unescaping strings, work on a copy
+	buf := make([]byte, len(data))
+	copy(buf, data)
+
+	n := len(bounds) >> 1
+	chunkSize := n / (p.workers << 2)
+	if chunkSize < 1 {
+		chunkSize = 1
+	} else if chunkSize > parallelMaxChunkSize {
+		chunkSize = parallelMaxChunkSize
+	}
+	// failed is the lowest failing index, n if none.
+	// Elements before it must still be decoded to find out whether one of them fails too.
+	failed := int64(n)
+	chunks := make(chan int, p.workers)
+	go func() {
+		for i := 0; i < n && int64(i) < atomic.LoadInt64(&failed); i += chunkSize {
+			chunks <- i
+		}
+		close(chunks)
+	}()
+
+	var results chan parallelElement
+	var window *parallelWindow
+	if p.onElement != nil {
+		results = make(chan parallelElement, p.workers<<2)
+		if p.ordered {
+			window = newParallelWindow(p.workers*chunkSize*parallelWindowChunks, &failed)
+		}
+	}
+	var mux sync.Mutex
+	var firstErr *ArrayElementError
+	var wg sync.WaitGroup
+	wg.Add(p.workers)
+	for w := 0; w < p.workers; w++ {
+		go func() {
+			defer wg.Done()
+			dec := borrowDecoder(nil, 0)
+			defer func() {
+				dec.data = nil
+				dec.Release()
+			}()
+			for start := range chunks {
+				end := start + chunkSize
+				if end > n {
+					end = n
+				}
+				for i := start; i < end && int64(i) < atomic.LoadInt64(&failed); i++ {
+					if window != nil && !window.wait(i) {
+						break
+					}
+					v := p.newElem(i)
+					if err := p.decodeElement(dec, buf, bounds, i, v); err != nil {
+						mux.Lock()
+						if firstErr == nil || i < firstErr.Index {
+							firstErr = &ArrayElementError{Index: i, Err: err}
+							atomic.StoreInt64(&failed, int64(i))
+						}
+						mux.Unlock()
+						if window != nil {
+							window.wakeUp()
+						}
+						break
+					}
+					if results != nil {
+						results <- parallelElement{i, v}
+					}
+				}
+			}
+		}()
+	}
+	if results != nil {
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+		p.deliver(results, window)
+	} else {
+		wg.Wait()
+	}
+	if firstErr != nil {
+		return *firstErr
+	}
+	return nil
+}
+
+// decodeElement decodes the i-th element of the array held in buf to v using dec.
+func (p *ParallelArrayDecoder) decodeElement(dec *Decoder, buf []byte, bounds []int, i int, v UnmarshalerObject) error {
+	s, e := bounds[i<<1], bounds[i<<1+1]
+	dec.data = buf[s:e:e]
+	dec.length = e - s
+	dec.cursor = 0
+	dec.err = nil
+	dec.called = 0
+	dec.keysDone = 0
+	// skip to the end of the object even if all keys are found
+	dec.child = 1
+	_, err := dec.decodeObject(v)
+	if err != nil {
+		return err
+	}
+	if dec.err != nil {
+		return dec.err
+	}
+	// the cursor goes past the end of data when the element is a null at the end of it
+	if dec.cursor > dec.length {
+		dec.cursor = dec.length
+	}
+	// the element must be a single value, like [{} {}] is rejected by the sequential decoder
+	if j := firstNonBlank(dec.data[dec.cursor:dec.length]); j >= 0 {
+		j += dec.cursor
+		return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[j], s+j))
+	}
+	return nil
+}
+
+func (p *ParallelArrayDecoder) deliver(results chan parallelElement, window *parallelWindow) {
+	if !p.ordered {
+		for r := range results {
+			p.onElement(r.i, r.v)
+		}
+		return
+	}
+	next := 0
+	pending := make(map[int]UnmarshalerObject)
+	for r := range results {
+		pending[r.i] = r.v
+		for v, ok := pending[next]; ok; v, ok = pending[next] {
+			delete(pending, next)
+			p.onElement(next, v)
+			next++
+		}
+		window.advance(next)
+	}
+}
+
+// parallelWindow limits how far ahead of the next element to deliver the workers can decode
+// when elements are delivered in order, so that a slow element doesn't make
+// all the following ones pile up in memory waiting for it.
+type parallelWindow struct {
+	mux    sync.Mutex
+	cond   *sync.Cond
+	next   int
+	size   int
+	failed *int64
+}
+
+func newParallelWindow(size int, failed *int64) *parallelWindow {
+	w := &parallelWindow{size: size, failed: failed}
+	w.cond = sync.NewCond(&w.mux)
+	return w
+}
+
+// wait blocks until the i-th element can be decoded.
+// It returns false if decoding failed at an index lower than i in the meantime.
+//
+// It can't block forever: chunks are handed out in order and each worker decodes its chunk in order,
+// so the next element to deliver is always being decoded or already decoded.
+func (w *parallelWindow) wait(i int) bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	for i >= w.next+w.size {
+		if int64(i) >= atomic.LoadInt64(w.failed) {
+			return false
+		}
+		w.cond.Wait()
+	}
+	return true
+}
+
+// advance records that the elements before next have been delivered.
+func (w *parallelWindow) advance(next int) {
+	w.mux.Lock()
+	w.next = next
+	w.mux.Unlock()
+	w.cond.Broadcast()
+}
+
+// wakeUp wakes up the waiting workers so that they check whether decoding failed.
+func (w *parallelWindow) wakeUp() {
+	// broadcast while holding the lock so that a worker can't miss it between checking and waiting
+	w.mux.Lock()
+	w.cond.Broadcast()
+	w.mux.Unlock()
+}
+
+// arrayElementBounds returns the start and end positions of each element
+// of the top level JSON array held in data.
+func arrayElementBounds(data []byte) ([]int, error) {
+	start := 0
+	for ; start < len(data); start++ {
+		switch data[start] {
+		case ' ', '\n', '\t', '\r':
+			continue
+		case '[':
+		case 'n':
+			dec := borrowDecoder(nil, 0)
+			dec.data = data[start+1:]
+			dec.length = len(dec.data)
+			err := dec.assertNull()
+			// the cursor is on the last l of null unless it is the end of data
+			if dec.cursor < dec.length {
+				dec.cursor++
+			}
+			if j := firstNonBlank(dec.data[dec.cursor:]); err == nil && j >= 0 {
+				j += start + 1 + dec.cursor
+				err = InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, data[j], j))
+			}
+			// don't keep a reference to data in the pool
+			dec.data = nil
+			dec.Release()
+			return nil, err
+		default:
+			return nil, InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to array, wrong char '%s' found at pos %d",
+					string(data[start]),
+					start,
+				),
+			)
+		}
+		break
+	}
+	if start == len(data) {
+		return nil, InvalidJSONError("Invalid JSON")
+	}
+	ix, err := buildStructuralIndex(data[start:])
+	if err != nil {
+		return nil, err
+	}
+	end := int(ix.match[0])
+	bounds := make([]int, 0, 64)
+	s := start + 1
+	for i := 1; i <= end; {
+		if m := int(ix.match[i]); m > i {
+			i = m + 1
+			continue
+		}
+		p := start + int(ix.pos[i])
+		switch data[p] {
+		case ',', ']':
+			if firstNonBlank(data[s:p]) < 0 {
+				// only an empty array can have no element before a separator,
+				// [,], [{},] and [{},,{}] are invalid
+				if data[p] == ',' || len(bounds) > 0 {
+					return nil, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, data[p], p))
+				}
+			} else {
+				bounds = append(bounds, s, p)
+			}
+			s = p + 1
+		default:
+			return nil, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, data[p], p))
+		}
+		i++
+	}
+	// only white spaces are allowed after the array
+	closing := start + int(ix.pos[end])
+	if j := firstNonBlank(data[closing+1:]); j >= 0 {
+		j += closing + 1
+		return nil, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, data[j], j))
+	}
+	return bounds, nil
+}
+
+// firstNonBlank returns the index of the first character of b which isn't a white space, -1 if none.
+func firstNonBlank(b []byte) int {
+	for i, c := range b {
+		switch c {
+		case ' ', '\n', '\t', '\r':
+			continue
+		}
+		return i
+	}
+	return -1
+}
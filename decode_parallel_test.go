@@ -0,0 +1,229 @@
+package gojay
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testParallelArrayJSON(n int) []byte {
+	elems := make([]string, n)
+	for i := range elems {
+		elems[i] = fmt.Sprintf(`{"test": %d, "test3": "str\"%d", "testSkip": [{"x": "],"}]}`, i, i)
+	}
+	return []byte("[\n" + strings.Join(elems, ",\n") + "\n]")
+}
+
+func TestDecodeArrayParallel(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		result := make([]TestObj, 1000)
+		err := DecodeArrayParallel(testParallelArrayJSON(1000), 4, func(i int) UnmarshalerObject {
+			return &result[i]
+		})
+		assert.Nil(t, err, "err should be nil")
+		for i := range result {
+			assert.Equal(t, i, result[i].test, "result[i].test should be i")
+			assert.Equal(t, fmt.Sprintf(`str"%d`, i), result[i].test3, "result[i].test3 is not expected value")
+		}
+	})
+	t.Run("ordered", func(t *testing.T) {
+		order := make([]int, 0, 500)
+		err := NewParallelArrayDecoder(8, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		}).Ordered().OnElement(func(i int, v UnmarshalerObject) {
+			assert.Equal(t, i, v.(*TestObj).test, "element should match its index")
+			order = append(order, i)
+		}).Decode(testParallelArrayJSON(500))
+		assert.Nil(t, err, "err should be nil")
+		assert.Len(t, order, 500, "all elements should have been delivered")
+		for i := range order {
+			assert.Equal(t, i, order[i], "elements should be delivered in order")
+		}
+	})
+	t.Run("unordered", func(t *testing.T) {
+		seen := make(map[int]bool)
+		err := NewParallelArrayDecoder(0, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		}).OnElement(func(i int, v UnmarshalerObject) {
+			seen[i] = true
+		}).Decode(testParallelArrayJSON(300))
+		assert.Nil(t, err, "err should be nil")
+		assert.Len(t, seen, 300, "all elements should have been delivered")
+	})
+	t.Run("empty-and-null", func(t *testing.T) {
+		newElem := func(i int) UnmarshalerObject {
+			t.Fatal("newElem should not be called")
+			return nil
+		}
+		assert.Nil(t, DecodeArrayParallel([]byte(` [ ] `), 2, newElem), "err should be nil")
+		assert.Nil(t, DecodeArrayParallel([]byte(`null`), 2, newElem), "err should be nil")
+	})
+	t.Run("element-error", func(t *testing.T) {
+		json := []byte(`[{"test": 1}, {"test": 2}, {"test": "str"}, {"test": 4}, {"test": "str"}]`)
+		err := DecodeArrayParallel(json, 2, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		})
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, ArrayElementError{}, err, "err should be of type ArrayElementError")
+		assert.Equal(t, 2, err.(ArrayElementError).Index, "err should report the first failing index")
+		assert.IsType(t, InvalidTypeError(""), err.(ArrayElementError).Err, "err.Err should be of type InvalidTypeError")
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		newElem := func(i int) UnmarshalerObject {
+			return &TestObj{}
+		}
+		err := DecodeArrayParallel([]byte(`[{"test": 1}, {"test": 2}`), 2, newElem)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		err = DecodeArrayParallel([]byte(`[{"test": 1}: {"test": 2}]`), 2, newElem)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		err = DecodeArrayParallel([]byte(`{"test": 1}`), 2, newElem)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	})
+	t.Run("invalid-separators", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			json string
+		}{
+			{name: "empty-element", json: `[{"test":1},,{"test":2}]`},
+			{name: "trailing-comma", json: `[{"test":1},]`},
+			{name: "trailing-comma-space", json: `[{}, ]`},
+			{name: "only-comma", json: `[,]`},
+			{name: "leading-comma", json: `[,{"test":1}]`},
+			{name: "missing-comma", json: `[{"test":1} {"test":2}]`},
+			{name: "missing-comma-value", json: `[{"test":1}, {"test":2} "x"]`},
+			{name: "trailing-garbage", json: `[{"test":1}] garbage`},
+			{name: "null-trailing-garbage", json: `null x`},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				err := DecodeArrayParallel([]byte(testCase.json), 2, func(i int) UnmarshalerObject {
+					return &TestObj{}
+				})
+				assert.NotNil(t, err, "err should not be nil")
+				if e, ok := err.(ArrayElementError); ok {
+					err = e.Err
+				}
+				assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+			})
+		}
+	})
+	t.Run("missing-comma-index", func(t *testing.T) {
+		json := []byte(`[{"test":0}, {"test":1}, {"test":2} {"test":3}]`)
+		err := DecodeArrayParallel(json, 2, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		})
+		assert.IsType(t, ArrayElementError{}, err, "err should be of type ArrayElementError")
+		assert.Equal(t, 2, err.(ArrayElementError).Index, "err should report the index of the element")
+		assert.Equal(
+			t,
+			fmt.Sprintf(invalidJSONCharErrorMsg, '{', strings.LastIndex(string(json), "{")),
+			err.(ArrayElementError).Err.Error(),
+			"err.Err should report the position in data",
+		)
+	})
+	t.Run("all-keys-found", func(t *testing.T) {
+		// TestObj has a fixed number of keys, the end of the element must still be checked
+		json := []byte(`[{"test":1,"test2":2,"test3":"a","test4":"b","test5":5.5,"testArr":[],"testSubObj":{},"testSubSubObj":{}, "extra": 1} x]`)
+		err := DecodeArrayParallel(json, 1, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		})
+		assert.NotNil(t, err, "err should not be nil")
+	})
+	t.Run("null-elements", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			json     string
+			expected []int
+		}{
+			{name: "only-null", json: `[null]`, expected: []int{-1}},
+			{name: "last-null", json: `[{"test":1}, null]`, expected: []int{1, -1}},
+			{name: "first-null", json: `[null ,{"test":2}]`, expected: []int{-1, 2}},
+			{name: "nulls", json: `[null,null,{"test":3},null]`, expected: []int{-1, -1, 3, -1}},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				result := make([]TestObj, len(testCase.expected))
+				for i := range result {
+					result[i].test = -1
+				}
+				err := DecodeArrayParallel([]byte(testCase.json), 2, func(i int) UnmarshalerObject {
+					return &result[i]
+				})
+				assert.Nil(t, err, "err should be nil")
+				for i, v := range testCase.expected {
+					assert.Equal(t, v, result[i].test, "result[i].test is not expected value")
+				}
+			})
+		}
+	})
+	t.Run("non-object-elements", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			json  string
+			index int
+		}{
+			{name: "number", json: `[1]`, index: 0},
+			{name: "string", json: `[{"test":1}, "x"]`, index: 1},
+			{name: "array", json: `[{"test":1}, {"test":2}, []]`, index: 2},
+			{name: "bool", json: `[true, {"test":1}]`, index: 0},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				err := DecodeArrayParallel([]byte(testCase.json), 2, func(i int) UnmarshalerObject {
+					return &TestObj{}
+				})
+				assert.IsType(t, ArrayElementError{}, err, "err should be of type ArrayElementError")
+				assert.Equal(t, testCase.index, err.(ArrayElementError).Index, "err.Index is not expected value")
+				assert.IsType(t, InvalidTypeError(""), err.(ArrayElementError).Err, "err.Err should be of type InvalidTypeError")
+			})
+		}
+	})
+	t.Run("lowest-failing-index", func(t *testing.T) {
+		json := []byte(`[{"test":0}, {"test":"slow"}, {"test":2}, {"test":"fast"}, {"test":4}]`)
+		err := DecodeArrayParallel(json, 2, func(i int) UnmarshalerObject {
+			if i == 1 {
+				time.Sleep(20 * time.Millisecond)
+			}
+			return &TestObj{}
+		})
+		assert.IsType(t, ArrayElementError{}, err, "err should be of type ArrayElementError")
+		assert.Equal(t, 1, err.(ArrayElementError).Index, "err should report the lowest failing index")
+	})
+	t.Run("ordered-window", func(t *testing.T) {
+		n := 10000
+		var ahead int64
+		var firstDone int32
+		delivered := 0
+		err := NewParallelArrayDecoder(2, func(i int) UnmarshalerObject {
+			if i == 0 {
+				time.Sleep(50 * time.Millisecond)
+				atomic.StoreInt32(&firstDone, 1)
+			} else if atomic.LoadInt32(&firstDone) == 0 && int64(i) > atomic.LoadInt64(&ahead) {
+				atomic.StoreInt64(&ahead, int64(i))
+			}
+			return &TestObj{}
+		}).Ordered().OnElement(func(i int, v UnmarshalerObject) {
+			assert.Equal(t, delivered, i, "elements should be delivered in order")
+			delivered++
+		}).Decode(testParallelArrayJSON(n))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, n, delivered, "all elements should have been delivered")
+		window := int64(2 * parallelMaxChunkSize * parallelWindowChunks)
+		assert.True(t, atomic.LoadInt64(&ahead) < window, "workers should not decode past the window while the first element is slow")
+	})
+	t.Run("whitespaces", func(t *testing.T) {
+		n := 0
+		err := NewParallelArrayDecoder(2, func(i int) UnmarshalerObject {
+			return &TestObj{}
+		}).OnElement(func(i int, v UnmarshalerObject) {
+			n++
+		}).Decode([]byte(" \n[ {\"test\":1} ,\t{\"test\":2}\n ] \n"))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, n, "all elements should have been delivered")
+		assert.Nil(t, DecodeArrayParallel([]byte(` null `), 2, nil), "err should be nil")
+	})
+}
@@ -0,0 +1,111 @@
+package gojay
+
+import "fmt"
+
+// Kind is the kind of a JSON value.
+type Kind byte
+
+const (
+	// KindInvalid is returned by PeekKind when no valid value can be found.
+	KindInvalid Kind = iota
+	// KindString is a JSON string.
+	KindString
+	// KindNumber is a JSON number.
+	KindNumber
+	// KindObject is a JSON object.
+	KindObject
+	// KindArray is a JSON array.
+	KindArray
+	// KindBool is a JSON boolean.
+	KindBool
+	// KindNull is the JSON null literal.
+	KindNull
+)
+
+var kindNames = [...]string{
+	KindInvalid: "invalid",
+	KindString:  "string",
+	KindNumber:  "number",
+	KindObject:  "object",
+	KindArray:   "array",
+	KindBool:    "bool",
+	KindNull:    "null",
+}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return kindNames[KindInvalid]
+}
+
+// PeekKind returns the kind of the next value without consuming it.
+// It returns KindInvalid if the input is over or if the next char can't start a JSON value.
+//
+// It is meant to be used inside UnmarshalObject or UnmarshalArray to choose
+// which Add* method to call.
+func (dec *Decoder) PeekKind() Kind {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '"':
+			return KindString
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '-':
+			return KindNumber
+		case 'N', 'I':
+			if dec.allowNonFinite {
+				return KindNumber
+			}
+			return KindInvalid
+		case '{':
+			return KindObject
+		case '[':
+			return KindArray
+		case 't', 'f':
+			return KindBool
+		case 'n':
+			return KindNull
+		default:
+			return KindInvalid
+		}
+	}
+	return KindInvalid
+}
+
+// AddValue decodes the next key using f, which receives the kind of the value.
+// It is meant for union typed fields, for example a number or an object:
+//
+//	return dec.AddValue(func(dec *gojay.Decoder, k gojay.Kind) error {
+//		switch k {
+//		case gojay.KindNumber:
+//			return dec.AddFloat(&p.amount)
+//		case gojay.KindObject:
+//			return dec.AddObject(p)
+//		}
+//		return nil
+//	})
+//
+// If f doesn't decode the value, it is skipped.
+func (dec *Decoder) AddValue(f func(*Decoder, Kind) error) error {
+	k := dec.PeekKind()
+	if k == KindInvalid {
+		if dec.cursor < dec.length {
+			return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[dec.cursor], dec.cursor))
+		}
+		return InvalidJSONError("Invalid JSON")
+	}
+	dec.called &= 0
+	err := f(dec, k)
+	if err != nil {
+		return err
+	}
+	if dec.called&1 == 0 {
+		err := dec.skipData()
+		if err != nil {
+			return err
+		}
+	}
+	dec.called |= 1
+	return nil
+}
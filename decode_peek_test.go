@@ -0,0 +1,130 @@
+package gojay
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPrice struct {
+	amount   float64
+	currency string
+	id       string
+	n        int
+}
+
+func (p *testPrice) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "amount":
+		return dec.AddFloat(&p.amount)
+	case "currency":
+		return dec.AddString(&p.currency)
+	case "price":
+		return dec.AddValue(func(dec *Decoder, k Kind) error {
+			switch k {
+			case KindNumber:
+				return dec.AddFloat(&p.amount)
+			case KindObject:
+				return dec.AddObject(p)
+			}
+			return nil
+		})
+	case "id":
+		if dec.PeekKind() == KindNumber {
+			var id int
+			err := dec.AddInt(&id)
+			p.id = "#" + strconv.Itoa(id)
+			return err
+		}
+		return dec.AddString(&p.id)
+	case "n":
+		return dec.AddInt(&p.n)
+	}
+	return nil
+}
+
+func (p *testPrice) NKeys() int {
+	return 0
+}
+
+func TestDecoderPeekKind(t *testing.T) {
+	testCases := []struct {
+		name         string
+		json         string
+		expectedKind Kind
+	}{
+		{name: "string", json: ` "str"`, expectedKind: KindString},
+		{name: "number", json: `12`, expectedKind: KindNumber},
+		{name: "negative-number", json: `-12`, expectedKind: KindNumber},
+		{name: "object", json: `{}`, expectedKind: KindObject},
+		{name: "array", json: "\n[]", expectedKind: KindArray},
+		{name: "true", json: `true`, expectedKind: KindBool},
+		{name: "false", json: `false`, expectedKind: KindBool},
+		{name: "null", json: `null`, expectedKind: KindNull},
+		{name: "nan", json: `NaN`, expectedKind: KindInvalid},
+		{name: "invalid", json: `}`, expectedKind: KindInvalid},
+		{name: "empty", json: ` `, expectedKind: KindInvalid},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			assert.Equal(t, testCase.expectedKind, dec.PeekKind(), "kind is not expected value")
+			// peeking twice doesn't consume
+			assert.Equal(t, testCase.expectedKind, dec.PeekKind(), "kind is not expected value")
+		})
+	}
+	t.Run("kind-string", func(t *testing.T) {
+		assert.Equal(t, "object", KindObject.String(), "KindObject.String() is not expected value")
+		assert.Equal(t, "invalid", Kind(42).String(), "Kind(42).String() is not expected value")
+	})
+}
+
+func TestDecoderAddValue(t *testing.T) {
+	testCases := []struct {
+		name             string
+		json             string
+		expectedAmount   float64
+		expectedCurrency string
+		expectedID       string
+	}{
+		{
+			name:           "number",
+			json:           `{"id": 1, "price": 12.5, "n": 1}`,
+			expectedAmount: 12.5,
+			expectedID:     "#1",
+		},
+		{
+			name:             "object",
+			json:             `{"id": "abc", "price": {"amount": 10, "currency": "EUR"}, "n": 1}`,
+			expectedAmount:   10,
+			expectedCurrency: "EUR",
+			expectedID:       "abc",
+		},
+		{
+			name: "skipped",
+			json: `{"price": [1, {"amount": 2}], "n": 1}`,
+		},
+		{
+			name: "null",
+			json: `{"price": null, "n": 1}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := &testPrice{}
+			err := Unmarshal([]byte(testCase.json), v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expectedAmount, v.amount, "v.amount is not expected value")
+			assert.Equal(t, testCase.expectedCurrency, v.currency, "v.currency is not expected value")
+			assert.Equal(t, testCase.expectedID, v.id, "v.id is not expected value")
+			assert.Equal(t, 1, v.n, "v.n should be 1")
+		})
+	}
+	t.Run("invalid-json", func(t *testing.T) {
+		v := &testPrice{}
+		err := Unmarshal([]byte(`{"price": }`), v)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+}
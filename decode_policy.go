@@ -0,0 +1,112 @@
+package gojay
+
+import "strconv"
+
+// ErrorPolicy defines how a Decoder handles JSON values which can't be decoded
+// to the receiver type (a string where an int is expected, an overflowing number, ...).
+//
+// Invalid JSON always stops decoding, whatever the policy.
+type ErrorPolicy byte
+
+const (
+	// ReportLast skips mismatching values and continues decoding.
+	// The last mismatch is returned by the Unmarshal functions once decoding is done,
+	// Decoder methods only return errors stopping the decoding. It is the default policy.
+	ReportLast ErrorPolicy = iota
+	// FailFast stops decoding at the first mismatch and returns it as a MismatchError.
+	FailFast
+	// CollectAll skips mismatching values and continues decoding.
+	// All mismatches are returned once decoding is done as a MultiError of MismatchError.
+	//
+	// With FailFast and CollectAll, each call to a Decoder method returns the mismatches found during the call.
+	CollectAll
+	// IgnoreMismatches silently skips mismatching values.
+	IgnoreMismatches
+)
+
+// SetErrorPolicy sets the policy used by the decoder to handle type mismatches.
+func (dec *Decoder) SetErrorPolicy(p ErrorPolicy) {
+	dec.policy = p
+}
+
+// resetPolicy prepares the decoder for a call to an exported Decode method.
+// Unless the policy is the default one, the mismatches recorded by the previous call
+// are cleared so that each call returns its own.
+func (dec *Decoder) resetPolicy() {
+	dec.path = dec.path[:0]
+	if dec.policy != ReportLast {
+		dec.err = nil
+		dec.errs = nil
+	}
+}
+
+// policyErr returns the error of an exported Decode method.
+// If decoding didn't fail, it is the error recorded by the policy unless the policy is the default one,
+// which keeps the historical behaviour of only returning errors stopping the decoding.
+func (dec *Decoder) policyErr(err error) error {
+	err = dec.ctxErr(err)
+	if err == nil && dec.policy != ReportLast {
+		return dec.err
+	}
+	return err
+}
+
+// pathElem is an element of the path to the value being decoded,
+// index is -1 for object keys.
+type pathElem struct {
+	key   string
+	index int
+}
+
+// tracksPath reports whether the path to the current value must be tracked
+// to be reported in mismatch errors.
+func (dec *Decoder) tracksPath() bool {
+	return dec.policy == FailFast || dec.policy == CollectAll
+}
+
+// pathString returns the current path as a JSONPath expression.
+func (dec *Decoder) pathString() string {
+	b := make([]byte, 1, 16)
+	b[0] = '$'
+	for _, e := range dec.path {
+		if e.index < 0 {
+			b = append(b, '.')
+			b = append(b, e.key...)
+			continue
+		}
+		b = append(b, '[')
+		b = strconv.AppendInt(b, int64(e.index), 10)
+		b = append(b, ']')
+	}
+	return string(b)
+}
+
+// mismatch handles a value at the cursor which can't be decoded to the receiver type.
+// It records err according to the error policy and skips the value,
+// unless the policy is FailFast in which case the recorded error is returned.
+func (dec *Decoder) mismatch(err error) error {
+	dec.recordMismatch(err)
+	if dec.policy == FailFast {
+		return dec.err
+	}
+	return dec.skipData()
+}
+
+// recordMismatch records err according to the error policy.
+func (dec *Decoder) recordMismatch(err error) {
+	switch dec.policy {
+	case IgnoreMismatches:
+	case FailFast:
+		dec.err = MismatchError{Path: dec.pathString(), Pos: dec.cursor, Err: err}
+	case CollectAll:
+		dec.errs = append(dec.errs, MismatchError{Path: dec.pathString(), Pos: dec.cursor, Err: err})
+		dec.err = dec.errs
+	default:
+		dec.err = err
+	}
+}
+
+// failed reports whether decoding must stop because of the error policy.
+func (dec *Decoder) failed() bool {
+	return dec.policy == FailFast && dec.err != nil
+}
@@ -0,0 +1,215 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicyJSON = `{
+	"test": "str",
+	"test2": 2,
+	"testSubObj": {"test": 1, "test2": "x"},
+	"testArr": [{"test": 1}, {"test3": 3}],
+	"test3": "ok"
+}`
+
+func TestDecoderErrorPolicy(t *testing.T) {
+	t.Run("report-last", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(testPolicyJSON))
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "Decoder methods should not return mismatches with the default policy")
+		v = &TestObj{}
+		err = UnmarshalObject([]byte(testPolicyJSON), v)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.True(t, strings.Contains(err.Error(), "string"), "err should be the last mismatch")
+		assert.Equal(t, 2, v.test2, "v.test2 should be 2")
+		assert.Equal(t, "ok", v.test3, "v.test3 should be ok")
+	})
+	t.Run("fail-fast", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(testPolicyJSON))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeObject(v)
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$.test", err.(MismatchError).Path, "err.Path is not expected value")
+		assert.Equal(t, strings.Index(testPolicyJSON, `"str"`), err.(MismatchError).Pos, "err.Pos is not expected value")
+		assert.IsType(t, InvalidTypeError(""), err.(MismatchError).Err, "err.Err should be of type InvalidTypeError")
+		assert.Equal(t, 0, v.test2, "decoding should have stopped before test2")
+	})
+	t.Run("fail-fast-nested", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{"testArr": [{"test": 1}, {"test3": 3}], "test": 1}`))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$.testArr[1].test3", err.(MismatchError).Path, "err.Path is not expected value")
+		assert.Equal(t, 0, v.test, "decoding should have stopped before test")
+	})
+	t.Run("collect-all", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(testPolicyJSON))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		errs := err.(MultiError)
+		assert.Len(t, errs, 3, "all mismatches should be collected")
+		paths := make([]string, len(errs))
+		for i, e := range errs {
+			paths[i] = e.(MismatchError).Path
+		}
+		assert.Equal(t, []string{"$.test", "$.testSubObj.test2", "$.testArr[1].test3"}, paths, "paths are not expected value")
+		assert.Equal(t, strings.Index(testPolicyJSON, `"x"`), errs[1].(MismatchError).Pos, "errs[1].Pos is not expected value")
+		assert.Equal(t, 2, v.test2, "v.test2 should be 2")
+		assert.Equal(t, "ok", v.test3, "v.test3 should be ok")
+		assert.True(t, strings.HasPrefix(errs.Error(), "3 errors occurred:"), "errs.Error() is not expected value")
+	})
+	t.Run("collect-all-overflow", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{"test": 99999999999999999999, "test2": 1}`))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		assert.Len(t, err, 1, "overflow should be collected")
+		assert.Equal(t, "$.test", err.(MultiError)[0].(MismatchError).Path, "path is not expected value")
+		assert.Equal(t, 1, v.test2, "v.test2 should be 1")
+	})
+	t.Run("ignore", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(testPolicyJSON))
+		dec.SetErrorPolicy(IgnoreMismatches)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, v.test2, "v.test2 should be 2")
+		assert.Equal(t, "ok", v.test3, "v.test3 should be ok")
+		assert.Equal(t, 1, v.testSubObj.test3, "v.testSubObj.test3 should be 1")
+	})
+	t.Run("fail-fast-scalars", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			json string
+			v    interface{}
+		}{
+			{name: "int", json: `"1"`, v: new(int)},
+			{name: "int32", json: `"1"`, v: new(int32)},
+			{name: "uint32", json: `"1"`, v: new(uint32)},
+			{name: "int64", json: `"1"`, v: new(int64)},
+			{name: "uint64", json: `"1"`, v: new(uint64)},
+			{name: "float64", json: `"1"`, v: new(float64)},
+			{name: "bool", json: `"1"`, v: new(bool)},
+			{name: "string", json: `1`, v: new(string)},
+			{name: "array", json: `{}`, v: new(testSliceStrings)},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				dec := NewDecoder(strings.NewReader(testCase.json))
+				dec.SetErrorPolicy(FailFast)
+				err := dec.Decode(testCase.v)
+				assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+				assert.Equal(t, "$", err.(MismatchError).Path, "err.Path should be the root")
+			})
+		}
+	})
+	t.Run("fail-fast-overflow", func(t *testing.T) {
+		var v int
+		dec := NewDecoder(strings.NewReader(`99999999999999999999999`))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeInt(&v)
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$", err.(MismatchError).Path, "err.Path should be the root")
+	})
+	t.Run("collect-all-per-call", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader(`{"test": "str"} {"test": 1}`))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(&TestObj{})
+		assert.Len(t, err, 1, "first call should return its mismatch")
+		v := &TestObj{}
+		err = dec.DecodeObject(v)
+		assert.Nil(t, err, "second call should not return the mismatches of the first one")
+		assert.Equal(t, 1, v.test, "v.test should be 1")
+	})
+	t.Run("unmarshal-policy", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			policy ErrorPolicy
+			json   string
+			v      interface{}
+			check  func(t *testing.T, err error)
+		}{
+			{
+				name:   "object-collect-all",
+				policy: CollectAll,
+				json:   testPolicyJSON,
+				v:      &TestObj{},
+				check: func(t *testing.T, err error) {
+					assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+					assert.Len(t, err, 3, "all mismatches should be collected")
+				},
+			},
+			{
+				name:   "object-fail-fast",
+				policy: FailFast,
+				json:   testPolicyJSON,
+				v:      &TestObj{},
+				check: func(t *testing.T, err error) {
+					assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+				},
+			},
+			{
+				name:   "object-ignore",
+				policy: IgnoreMismatches,
+				json:   testPolicyJSON,
+				v:      &TestObj{},
+				check: func(t *testing.T, err error) {
+					assert.Nil(t, err, "err should be nil")
+				},
+			},
+			{
+				name:   "array-collect-all",
+				policy: CollectAll,
+				json:   `["a", 1, "b", 2]`,
+				v:      new(testSliceStrings),
+				check: func(t *testing.T, err error) {
+					assert.Len(t, err, 2, "all mismatches should be collected")
+				},
+			},
+			{
+				name:   "int-fail-fast",
+				policy: FailFast,
+				json:   `99999999999999999999999`,
+				v:      new(int),
+				check: func(t *testing.T, err error) {
+					assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+				},
+			},
+			{
+				name:   "invalid-type",
+				policy: CollectAll,
+				json:   `{}`,
+				v:      &struct{}{},
+				check: func(t *testing.T, err error) {
+					assert.IsType(t, InvalidUnmarshalError(""), err, "err should be of type InvalidUnmarshalError")
+				},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				testCase.check(t, UnmarshalPolicy([]byte(testCase.json), testCase.v, testCase.policy))
+			})
+		}
+	})
+	t.Run("pooled-decoder-reset", func(t *testing.T) {
+		dec := BorrowDecoder(strings.NewReader(`{"test": "str"}`))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(&TestObj{})
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		dec.Release()
+		dec = BorrowDecoder(strings.NewReader(`{"test": "str"}`))
+		defer dec.Release()
+		err = dec.DecodeObject(&TestObj{})
+		assert.Nil(t, err, "policy should be reset to ReportLast")
+	})
+}
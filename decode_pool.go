@@ -47,6 +47,16 @@ func borrowDecoder(r io.Reader, bufSize int) *Decoder {
 	dec.r = r
 	dec.length = 0
 	dec.isPooled = 0
+	dec.index = nil
+	dec.policy = ReportLast
+	dec.path = dec.path[:0]
+	dec.errs = nil
+	dec.ctx = nil
+	dec.watch = nil
+	dec.lenient = false
+	dec.lenientRead = false
+	dec.allowNonFinite = false
+	dec.quotedValues = false
 	if bufSize > 0 {
 		dec.data = make([]byte, bufSize)
 	}
@@ -57,6 +67,7 @@ func borrowDecoder(r io.Reader, bufSize int) *Decoder {
 // If a decoder is used after calling Release
 // a panic will be raised with an InvalidUsagePooledDecoderError error.
 func (dec *Decoder) Release() {
+	dec.unwatchContext()
 	dec.isPooled = 1
 	decPool.Put(dec)
 }
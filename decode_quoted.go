@@ -0,0 +1,234 @@
+package gojay
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// SetQuotedValues enables or disables decoding of numbers and booleans encoded as JSON strings,
+// like "42" or "true", with the regular methods (AddInt, AddFloat, AddBool, DecodeInt64, ...).
+// Plain JSON numbers and booleans are still accepted.
+//
+// If the content of the string is not a valid value for the receiver type,
+// an InvalidTypeError is reported according to the decoder's error policy.
+func (dec *Decoder) SetQuotedValues(b bool) {
+	dec.quotedValues = b
+}
+
+// AddIntFromString decodes the next key, a number encoded as a JSON string like "42", to an *int.
+// Plain JSON numbers are accepted as well.
+func (dec *Decoder) AddIntFromString(v *int) error {
+	q := dec.quotedValues
+	dec.quotedValues = true
+	err := dec.decodeInt(v)
+	dec.quotedValues = q
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddInt64FromString decodes the next key, a number encoded as a JSON string like "42", to an *int64.
+// Plain JSON numbers are accepted as well.
+func (dec *Decoder) AddInt64FromString(v *int64) error {
+	q := dec.quotedValues
+	dec.quotedValues = true
+	err := dec.decodeInt64(v)
+	dec.quotedValues = q
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddUint64FromString decodes the next key, a number encoded as a JSON string like "42", to an *uint64.
+// Plain JSON numbers are accepted as well.
+func (dec *Decoder) AddUint64FromString(v *uint64) error {
+	q := dec.quotedValues
+	dec.quotedValues = true
+	err := dec.decodeUint64(v)
+	dec.quotedValues = q
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddFloatFromString decodes the next key, a number encoded as a JSON string like "4.2", to a *float64.
+// Plain JSON numbers are accepted as well.
+func (dec *Decoder) AddFloatFromString(v *float64) error {
+	q := dec.quotedValues
+	dec.quotedValues = true
+	err := dec.decodeFloat64(v)
+	dec.quotedValues = q
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddBoolFromString decodes the next key, a boolean encoded as a JSON string like "true", to a *bool.
+// Plain JSON booleans are accepted as well.
+func (dec *Decoder) AddBoolFromString(v *bool) error {
+	q := dec.quotedValues
+	dec.quotedValues = true
+	err := dec.decodeBool(v)
+	dec.quotedValues = q
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// getQuoted reads the JSON string at the cursor and returns its content
+// along with the position of its opening quote.
+func (dec *Decoder) getQuoted() (string, int, error) {
+	pos := dec.cursor
+	dec.cursor = dec.cursor + 1
+	start, end, err := dec.getString()
+	if err != nil {
+		return "", pos, err
+	}
+	d := dec.data[start : end-1]
+	return *(*string)(unsafe.Pointer(&d)), pos, nil
+}
+
+// getQuotedInt returns the int held by the JSON string at the cursor.
+// ok is false if the string doesn't hold a valid int, the mismatch is then recorded
+// and err is only set if the error policy stops decoding.
+func (dec *Decoder) getQuotedInt(bitSize int) (v int64, ok bool, err error) {
+	s, pos, err := dec.getQuoted()
+	if err != nil {
+		return 0, false, err
+	}
+	if isJSONNumber(s) {
+		if v, err = strconv.ParseInt(s, 10, bitSize); err == nil {
+			return v, true, nil
+		}
+	}
+	return 0, false, dec.quotedMismatch("int", s, pos)
+}
+
+// getQuotedUint returns the uint held by the JSON string at the cursor, see getQuotedInt.
+func (dec *Decoder) getQuotedUint(bitSize int) (v uint64, ok bool, err error) {
+	s, pos, err := dec.getQuoted()
+	if err != nil {
+		return 0, false, err
+	}
+	if isJSONNumber(s) {
+		if v, err = strconv.ParseUint(s, 10, bitSize); err == nil {
+			return v, true, nil
+		}
+	}
+	return 0, false, dec.quotedMismatch("uint", s, pos)
+}
+
+// getQuotedFloat returns the float held by the JSON string at the cursor, see getQuotedInt.
+// NaN, Infinity and -Infinity are accepted if non finite numbers are allowed.
+func (dec *Decoder) getQuotedFloat() (v float64, ok bool, err error) {
+	s, pos, err := dec.getQuoted()
+	if err != nil {
+		return 0, false, err
+	}
+	if isJSONNumber(s) {
+		if v, err = strconv.ParseFloat(s, 64); err == nil {
+			return v, true, nil
+		}
+	} else if dec.allowNonFinite {
+		switch s {
+		case "NaN":
+			return math.NaN(), true, nil
+		case "Infinity":
+			return math.Inf(1), true, nil
+		case "-Infinity":
+			return math.Inf(-1), true, nil
+		}
+	}
+	return 0, false, dec.quotedMismatch("float", s, pos)
+}
+
+// getQuotedBool returns the bool held by the JSON string at the cursor, see getQuotedInt.
+func (dec *Decoder) getQuotedBool() (v bool, ok bool, err error) {
+	s, pos, err := dec.getQuoted()
+	if err != nil {
+		return false, false, err
+	}
+	switch s {
+	case "true":
+		return true, true, nil
+	case "false":
+		return false, true, nil
+	}
+	return false, false, dec.quotedMismatch("bool", s, pos)
+}
+
+// isJSONNumber reports whether s is a number as defined by the JSON grammar.
+// strconv accepts more forms, like +42, 042, 0x2a, 0x1p-2 or 1_000, which must be rejected.
+func isJSONNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i == len(s):
+		return false
+	case s[i] == '0':
+		i++
+	case s[i] >= '1' && s[i] <= '9':
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// quotedMismatch records an InvalidTypeError for the invalid quoted value s found at pos.
+func (dec *Decoder) quotedMismatch(t string, s string, pos int) error {
+	cursor := dec.cursor
+	dec.cursor = pos
+	dec.recordMismatch(InvalidTypeError(
+		fmt.Sprintf(
+			"Cannot unmarshall to %s, invalid quoted value '%s' found at pos %d",
+			t,
+			s,
+			pos,
+		),
+	))
+	dec.cursor = cursor
+	if dec.failed() {
+		return dec.err
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package gojay
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testQuoted struct {
+	id    int64
+	uid   uint64
+	n     int
+	price float64
+	ok    bool
+}
+
+func (v *testQuoted) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "id":
+		return dec.AddInt64FromString(&v.id)
+	case "uid":
+		return dec.AddUint64FromString(&v.uid)
+	case "n":
+		return dec.AddIntFromString(&v.n)
+	case "price":
+		return dec.AddFloatFromString(&v.price)
+	case "ok":
+		return dec.AddBoolFromString(&v.ok)
+	}
+	return nil
+}
+
+func (v *testQuoted) NKeys() int {
+	return 5
+}
+
+func TestDecoderAddFromString(t *testing.T) {
+	t.Run("quoted", func(t *testing.T) {
+		v := &testQuoted{}
+		err := UnmarshalObject(
+			[]byte(`{"id":"9007199254740993","uid":"18446744073709551615","n":"-3","price":"4.5","ok":"true"}`),
+			v,
+		)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, int64(9007199254740993), v.id, "v.id is not expected value")
+		assert.Equal(t, uint64(18446744073709551615), v.uid, "v.uid is not expected value")
+		assert.Equal(t, -3, v.n, "v.n is not expected value")
+		assert.Equal(t, 4.5, v.price, "v.price is not expected value")
+		assert.True(t, v.ok, "v.ok should be true")
+	})
+	t.Run("plain", func(t *testing.T) {
+		v := &testQuoted{}
+		err := UnmarshalObject([]byte(`{"id":1,"uid":2,"n":3,"price":4.5,"ok":true}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, int64(1), v.id, "v.id is not expected value")
+		assert.Equal(t, uint64(2), v.uid, "v.uid is not expected value")
+		assert.Equal(t, 3, v.n, "v.n is not expected value")
+		assert.Equal(t, 4.5, v.price, "v.price is not expected value")
+		assert.True(t, v.ok, "v.ok should be true")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		v := &testQuoted{}
+		dec := NewDecoder(strings.NewReader(`{"id":"12a","n":"3"}`))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$.id", err.(MismatchError).Path, "err.Path is not expected value")
+		assert.Equal(t, 6, err.(MismatchError).Pos, "err.Pos is not expected value")
+		assert.Equal(t, 0, v.n, "decoding should have stopped before n")
+	})
+	t.Run("int-range", func(t *testing.T) {
+		// the range of an int depends on the platform
+		maxInt := int(^uint(0) >> 1)
+		v := &testQuoted{}
+		err := UnmarshalObject([]byte(`{"n":"`+strconv.Itoa(maxInt)+`"}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, maxInt, v.n, "v.n is not expected value")
+		v = &testQuoted{n: 3}
+		err = UnmarshalObject([]byte(`{"n":"`+strconv.FormatUint(uint64(maxInt)+1, 10)+`"}`), v)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Equal(t, 3, v.n, "v.n should be left untouched")
+	})
+	t.Run("invalid-keeps-values", func(t *testing.T) {
+		v := &testQuoted{id: 1, uid: 2, n: 3, price: 4.5, ok: true}
+		err := UnmarshalObject(
+			[]byte(`{"id":"+42","uid":"-1","n":"0x2a","price":"0x1p-2","ok":"yes"}`),
+			v,
+		)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Equal(t, int64(1), v.id, "v.id should be left untouched")
+		assert.Equal(t, uint64(2), v.uid, "v.uid should be left untouched")
+		assert.Equal(t, 3, v.n, "v.n should be left untouched")
+		assert.Equal(t, 4.5, v.price, "v.price should be left untouched")
+		assert.True(t, v.ok, "v.ok should be left untouched")
+	})
+	t.Run("option-not-leaking", func(t *testing.T) {
+		v := &testQuoted{}
+		dec := NewDecoder(strings.NewReader(`{"n":"3"}`))
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.False(t, dec.quotedValues, "dec.quotedValues should be restored")
+	})
+}
+
+func TestDecoderQuotedValues(t *testing.T) {
+	testCases := []struct {
+		name          string
+		json          string
+		v             interface{}
+		expectedValue interface{}
+		err           bool
+	}{
+		{name: "int", json: `"42"`, v: new(int), expectedValue: 42},
+		{name: "int-negative", json: `"-42"`, v: new(int), expectedValue: -42},
+		{name: "int-plain", json: `42`, v: new(int), expectedValue: 42},
+		{name: "int32", json: `"-42"`, v: new(int32), expectedValue: int32(-42)},
+		{name: "int32-overflow", json: `"2147483648"`, v: new(int32), expectedValue: int32(0), err: true},
+		{name: "uint32", json: `"42"`, v: new(uint32), expectedValue: uint32(42)},
+		{name: "uint32-negative", json: `"-42"`, v: new(uint32), expectedValue: uint32(0), err: true},
+		{name: "int64", json: `"9223372036854775807"`, v: new(int64), expectedValue: int64(9223372036854775807)},
+		{name: "uint64", json: `"18446744073709551615"`, v: new(uint64), expectedValue: uint64(18446744073709551615)},
+		{name: "float64", json: `"1.5e3"`, v: new(float64), expectedValue: 1500.0},
+		{name: "float64-nan", json: `"NaN"`, v: new(float64), expectedValue: 0.0, err: true},
+		{name: "bool-true", json: `"true"`, v: new(bool), expectedValue: true},
+		{name: "bool-false", json: `"false"`, v: new(bool), expectedValue: false},
+		{name: "bool-invalid", json: `"yes"`, v: new(bool), expectedValue: false, err: true},
+		{name: "int-empty", json: `""`, v: new(int), expectedValue: 0, err: true},
+		{name: "int-plus-sign", json: `"+42"`, v: new(int), expectedValue: 0, err: true},
+		{name: "int-leading-zero", json: `"042"`, v: new(int), expectedValue: 0, err: true},
+		{name: "int-hex", json: `"0x2a"`, v: new(int64), expectedValue: int64(0), err: true},
+		{name: "int-underscore", json: `"1_000"`, v: new(int), expectedValue: 0, err: true},
+		{name: "uint64-plus-sign", json: `"+42"`, v: new(uint64), expectedValue: uint64(0), err: true},
+		{name: "float64-hex", json: `"0x1p-2"`, v: new(float64), expectedValue: 0.0, err: true},
+		{name: "float64-plus-sign", json: `"+1.5"`, v: new(float64), expectedValue: 0.0, err: true},
+		{name: "float64-no-fraction", json: `"1."`, v: new(float64), expectedValue: 0.0, err: true},
+		{name: "float64-inf", json: `"Inf"`, v: new(float64), expectedValue: 0.0, err: true},
+		{name: "float64-negative-zero-exp", json: `"-0.5E+2"`, v: new(float64), expectedValue: -50.0},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			dec.SetQuotedValues(true)
+			err := dec.Decode(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			if testCase.err {
+				assert.IsType(t, InvalidTypeError(""), dec.err, "dec.err should be of type InvalidTypeError")
+			} else {
+				assert.Nil(t, dec.err, "dec.err should be nil")
+			}
+			switch v := testCase.v.(type) {
+			case *int:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *int32:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *uint32:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *int64:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *uint64:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *float64:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			case *bool:
+				assert.Equal(t, testCase.expectedValue, *v, "v is not expected value")
+			}
+		})
+	}
+	t.Run("disabled", func(t *testing.T) {
+		var v int
+		dec := NewDecoder(strings.NewReader(`"42"`))
+		err := dec.DecodeInt(&v)
+		assert.Nil(t, err, "err should be nil")
+		assert.IsType(t, InvalidTypeError(""), dec.err, "dec.err should be of type InvalidTypeError")
+		assert.Equal(t, 0, v, "v should be 0")
+	})
+	t.Run("non-finite", func(t *testing.T) {
+		var v float64
+		dec := NewDecoder(strings.NewReader(`"-Infinity"`))
+		dec.SetQuotedValues(true)
+		dec.SetAllowNonFinite(true)
+		err := dec.DecodeFloat64(&v)
+		assert.Nil(t, err, "err should be nil")
+		assert.True(t, math.IsInf(v, -1), "v should be -Infinity")
+	})
+	t.Run("object", func(t *testing.T) {
+		v := &TestObj{}
+		dec := NewDecoder(strings.NewReader(`{"test":"1","test2":"2","test5":"1.5","test3":"str"}`))
+		dec.SetQuotedValues(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Nil(t, dec.err, "dec.err should be nil")
+		assert.Equal(t, 1, v.test, "v.test is not expected value")
+		assert.Equal(t, 2, v.test2, "v.test2 is not expected value")
+		assert.Equal(t, 1.5, v.test5, "v.test5 is not expected value")
+		assert.Equal(t, "str", v.test3, "v.test3 is not expected value")
+	})
+}
@@ -0,0 +1,189 @@
+package gojay
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// AddSQLNullString decodes the next key to a *sql.NullString.
+// If next key is null, Valid is false, otherwise Valid is true and String holds the decoded value.
+func (dec *Decoder) AddSQLNullString(v *sql.NullString) error {
+	err := dec.decodeSQLNullString(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddSQLNullInt64 decodes the next key to a *sql.NullInt64.
+// If next key is null, Valid is false, otherwise Valid is true and Int64 holds the decoded value.
+func (dec *Decoder) AddSQLNullInt64(v *sql.NullInt64) error {
+	err := dec.decodeSQLNullInt64(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddSQLNullFloat64 decodes the next key to a *sql.NullFloat64.
+// If next key is null, Valid is false, otherwise Valid is true and Float64 holds the decoded value.
+func (dec *Decoder) AddSQLNullFloat64(v *sql.NullFloat64) error {
+	err := dec.decodeSQLNullFloat64(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddSQLNullBool decodes the next key to a *sql.NullBool.
+// If next key is null, Valid is false, otherwise Valid is true and Bool holds the decoded value.
+func (dec *Decoder) AddSQLNullBool(v *sql.NullBool) error {
+	err := dec.decodeSQLNullBool(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddSQLNullTime decodes the next key, a RFC 3339 JSON string, to a *sql.NullTime.
+// If next key is null, Valid is false, otherwise Valid is true and Time holds the decoded value.
+func (dec *Decoder) AddSQLNullTime(v *sql.NullTime) error {
+	err := dec.decodeSQLNullTime(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// decodeSQLNull decodes the next value to v, which must be a pointer to one of the sql null types.
+func (dec *Decoder) decodeSQLNull(v interface{}) error {
+	switch vt := v.(type) {
+	case *sql.NullString:
+		return dec.decodeSQLNullString(vt)
+	case *sql.NullInt64:
+		return dec.decodeSQLNullInt64(vt)
+	case *sql.NullFloat64:
+		return dec.decodeSQLNullFloat64(vt)
+	case *sql.NullBool:
+		return dec.decodeSQLNullBool(vt)
+	case *sql.NullTime:
+		return dec.decodeSQLNullTime(vt)
+	}
+	return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(v).String()))
+}
+
+func (dec *Decoder) decodeSQLNullString(v *sql.NullString) error {
+	k := dec.PeekKind()
+	switch k {
+	case KindNull:
+		*v = sql.NullString{}
+		return dec.decodeNull()
+	case KindString:
+		err := dec.decodeString(&v.String)
+		if err != nil {
+			return err
+		}
+		v.Valid = true
+		return nil
+	}
+	// reports the mismatch
+	var s string
+	return dec.decodeString(&s)
+}
+
+func (dec *Decoder) decodeSQLNullInt64(v *sql.NullInt64) error {
+	k := dec.PeekKind()
+	switch {
+	case k == KindNull:
+		*v = sql.NullInt64{}
+		return dec.decodeNull()
+	case k == KindNumber || (k == KindString && dec.quotedValues):
+		err := dec.decodeInt64(&v.Int64)
+		if err != nil {
+			return err
+		}
+		v.Valid = true
+		return nil
+	}
+	// reports the mismatch
+	var i int64
+	return dec.decodeInt64(&i)
+}
+
+func (dec *Decoder) decodeSQLNullFloat64(v *sql.NullFloat64) error {
+	k := dec.PeekKind()
+	switch {
+	case k == KindNull:
+		*v = sql.NullFloat64{}
+		return dec.decodeNull()
+	case k == KindNumber || (k == KindString && dec.quotedValues):
+		err := dec.decodeFloat64(&v.Float64)
+		if err != nil {
+			return err
+		}
+		v.Valid = true
+		return nil
+	}
+	// reports the mismatch
+	var f float64
+	return dec.decodeFloat64(&f)
+}
+
+func (dec *Decoder) decodeSQLNullBool(v *sql.NullBool) error {
+	k := dec.PeekKind()
+	switch {
+	case k == KindNull:
+		*v = sql.NullBool{}
+		return dec.decodeNull()
+	case k == KindBool || (k == KindString && dec.quotedValues):
+		err := dec.decodeBool(&v.Bool)
+		if err != nil {
+			return err
+		}
+		v.Valid = true
+		return nil
+	}
+	// reports the mismatch
+	var b bool
+	return dec.decodeBool(&b)
+}
+
+func (dec *Decoder) decodeSQLNullTime(v *sql.NullTime) error {
+	switch dec.PeekKind() {
+	case KindNull:
+		*v = sql.NullTime{}
+		return dec.decodeNull()
+	case KindString:
+		s, pos, err := dec.getQuoted()
+		if err != nil {
+			return err
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return dec.quotedMismatch("time", s, pos)
+		}
+		v.Time, v.Valid = t, true
+		return nil
+	}
+	// reports the mismatch
+	var s string
+	return dec.decodeString(&s)
+}
+
+// decodeNull consumes the null literal at the cursor.
+func (dec *Decoder) decodeNull() error {
+	dec.cursor++
+	err := dec.assertNull()
+	if err != nil {
+		return err
+	}
+	dec.cursor++
+	return nil
+}
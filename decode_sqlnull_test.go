@@ -0,0 +1,126 @@
+package gojay
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSQLNullObj struct {
+	s  sql.NullString
+	i  sql.NullInt64
+	f  sql.NullFloat64
+	b  sql.NullBool
+	t  sql.NullTime
+	id int
+}
+
+func (o *testSQLNullObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "s":
+		return dec.AddSQLNullString(&o.s)
+	case "i":
+		return dec.AddSQLNullInt64(&o.i)
+	case "f":
+		return dec.AddSQLNullFloat64(&o.f)
+	case "b":
+		return dec.AddSQLNullBool(&o.b)
+	case "t":
+		return dec.AddSQLNullTime(&o.t)
+	case "id":
+		return dec.AddInt(&o.id)
+	}
+	return nil
+}
+
+func (o *testSQLNullObj) NKeys() int {
+	return 6
+}
+
+func TestDecoderSQLNull(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := &testSQLNullObj{}
+		err := UnmarshalObject(
+			[]byte(`{"s":"str","i":-12,"f":1.5,"b":false,"t":"2019-03-01T10:00:00.5Z","id":1}`),
+			v,
+		)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, sql.NullString{String: "str", Valid: true}, v.s, "v.s is not expected value")
+		assert.Equal(t, sql.NullInt64{Int64: -12, Valid: true}, v.i, "v.i is not expected value")
+		assert.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, v.f, "v.f is not expected value")
+		assert.Equal(t, sql.NullBool{Bool: false, Valid: true}, v.b, "v.b is not expected value")
+		assert.True(t, v.t.Valid, "v.t.Valid should be true")
+		assert.True(
+			t,
+			v.t.Time.Equal(time.Date(2019, 3, 1, 10, 0, 0, 500000000, time.UTC)),
+			"v.t.Time is not expected value",
+		)
+		assert.Equal(t, 1, v.id, "v.id is not expected value")
+	})
+	t.Run("null", func(t *testing.T) {
+		v := &testSQLNullObj{
+			s: sql.NullString{String: "str", Valid: true},
+			i: sql.NullInt64{Int64: 1, Valid: true},
+			f: sql.NullFloat64{Float64: 1, Valid: true},
+			b: sql.NullBool{Bool: true, Valid: true},
+			t: sql.NullTime{Time: time.Now(), Valid: true},
+		}
+		err := UnmarshalObject([]byte(`{"s":null,"i":null,"f":null,"b":null,"t":null,"id":1}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, sql.NullString{}, v.s, "v.s should be reset")
+		assert.Equal(t, sql.NullInt64{}, v.i, "v.i should be reset")
+		assert.Equal(t, sql.NullFloat64{}, v.f, "v.f should be reset")
+		assert.Equal(t, sql.NullBool{}, v.b, "v.b should be reset")
+		assert.Equal(t, sql.NullTime{}, v.t, "v.t should be reset")
+		assert.Equal(t, 1, v.id, "v.id is not expected value")
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			json string
+		}{
+			{name: "string", json: `{"s":1,"id":1}`},
+			{name: "int64", json: `{"i":"1","id":1}`},
+			{name: "float64", json: `{"f":true,"id":1}`},
+			{name: "bool", json: `{"b":{},"id":1}`},
+			{name: "time", json: `{"t":"yesterday","id":1}`},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				v := &testSQLNullObj{}
+				dec := NewDecoder(strings.NewReader(testCase.json))
+				dec.SetErrorPolicy(CollectAll)
+				err := dec.DecodeObject(v)
+				assert.Len(t, err, 1, "mismatch should be collected")
+				assert.False(t, v.s.Valid || v.i.Valid || v.f.Valid || v.b.Valid || v.t.Valid, "no value should be valid")
+				assert.Equal(t, 1, v.id, "v.id is not expected value")
+			})
+		}
+	})
+	t.Run("quoted-values", func(t *testing.T) {
+		v := &testSQLNullObj{}
+		dec := NewDecoder(strings.NewReader(`{"i":"42","b":"true"}`))
+		dec.SetQuotedValues(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, v.i, "v.i is not expected value")
+		assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, v.b, "v.b is not expected value")
+	})
+	t.Run("decode-api", func(t *testing.T) {
+		var s sql.NullString
+		err := NewDecoder(strings.NewReader(`"str"`)).Decode(&s)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, sql.NullString{String: "str", Valid: true}, s, "s is not expected value")
+		var i sql.NullInt64
+		err = Unmarshal([]byte(`null`), &i)
+		assert.Nil(t, err, "err should be nil")
+		assert.False(t, i.Valid, "i.Valid should be false")
+		var f sql.NullFloat64
+		err = Unsafe.Unmarshal([]byte(`2.5`), &f)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, sql.NullFloat64{Float64: 2.5, Valid: true}, f, "f is not expected value")
+	})
+}
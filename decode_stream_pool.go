@@ -41,6 +41,15 @@ func (s stream) borrowDecoder(r io.Reader, bufSize int) *StreamDecoder {
 	streamDec.r = r
 	streamDec.length = 0
 	streamDec.isPooled = 0
+	streamDec.index = nil
+	streamDec.policy = ReportLast
+	streamDec.path = streamDec.path[:0]
+	streamDec.errs = nil
+	streamDec.ctx = nil
+	streamDec.lenient = false
+	streamDec.lenientRead = false
+	streamDec.allowNonFinite = false
+	streamDec.quotedValues = false
 	streamDec.done = make(chan struct{}, 1)
 	if bufSize > 0 {
 		streamDec.data = make([]byte, bufSize)
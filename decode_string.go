@@ -12,7 +12,8 @@ func (dec *Decoder) DecodeString(v *string) error {
 	if dec.isPooled == 1 {
 		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
 	}
-	return dec.decodeString(v)
+	dec.resetPolicy()
+	return dec.policyErr(dec.decodeString(v))
 }
 func (dec *Decoder) decodeString(v *string) error {
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
@@ -41,14 +42,13 @@ func (dec *Decoder) decodeString(v *string) error {
 			dec.cursor++
 			return nil
 		default:
-			dec.err = InvalidTypeError(
+			err := dec.mismatch(InvalidTypeError(
 				fmt.Sprintf(
 					"Cannot unmarshall to string, wrong char '%s' found at pos %d",
 					string(dec.data[dec.cursor]),
 					dec.cursor,
 				),
-			)
-			err := dec.skipData()
+			))
 			if err != nil {
 				return err
 			}
@@ -166,10 +166,16 @@ func (dec *Decoder) getString() (int, int, error) {
 		// slash found
 		case '\\':
 			dec.cursor = dec.cursor + 1
+			l := dec.length
 			err := dec.parseEscapedString()
 			if err != nil {
 				return 0, 0, err
 			}
+			// unescaping shifts the rest of the buffer to the left,
+			// keep track of it so the structural index stays valid
+			if dec.index != nil {
+				dec.index.shift += l - dec.length
+			}
 		default:
 			dec.cursor = dec.cursor + 1
 			continue
@@ -207,6 +213,13 @@ func (dec *Decoder) skipEscapedString() error {
 }
 
 func (dec *Decoder) skipString() error {
+	// with a structural index, jump straight to the closing quote
+	if dec.index != nil {
+		if end, ok := dec.index.skip(dec.data, dec.cursor); ok {
+			dec.cursor = end
+			return nil
+		}
+	}
 	for dec.cursor < dec.length || dec.read() {
 		switch dec.data[dec.cursor] {
 		// string found
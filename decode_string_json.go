@@ -0,0 +1,109 @@
+package gojay
+
+import "fmt"
+
+// AddObjectFromString decodes the next key, a JSON string holding an encoded JSON object, to v.
+// It is meant for double encoded payloads like {"body":"{\"id\":1}"}.
+// If next key is null, v is left untouched.
+func (dec *Decoder) AddObjectFromString(v UnmarshalerObject) error {
+	child, err := dec.stringDecoder()
+	if err != nil || child == nil {
+		return err
+	}
+	_, err = child.decodeObject(v)
+	return dec.endStringDecoder(child, err)
+}
+
+// AddArrayFromString decodes the next key, a JSON string holding an encoded JSON array, to v.
+// It is meant for double encoded payloads like {"items":"[1,2]"}.
+// If next key is null, v is left untouched.
+func (dec *Decoder) AddArrayFromString(v UnmarshalerArray) error {
+	child, err := dec.stringDecoder()
+	if err != nil || child == nil {
+		return err
+	}
+	_, err = child.decodeArray(v)
+	return dec.endStringDecoder(child, err)
+}
+
+// stringDecoder unescapes the JSON string at the cursor and returns a decoder
+// reading its content with the same options. It returns nil if the value is null.
+func (dec *Decoder) stringDecoder() (*Decoder, error) {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '"':
+			dec.cursor = dec.cursor + 1
+			start, end, err := dec.getString()
+			if err != nil {
+				return nil, err
+			}
+			dec.called |= 1
+			child := borrowDecoder(nil, 0)
+			// limit capacity so that unescaping in the child never touches the rest of the buffer
+			child.data = dec.data[start : end-1 : end-1]
+			child.length = end - 1 - start
+			child.ctx = dec.ctx
+			child.policy = dec.policy
+			child.path = dec.path
+			child.allowNonFinite = dec.allowNonFinite
+			child.quotedValues = dec.quotedValues
+			// the parent input is normalized as a whole, the content of its strings is not
+			if dec.lenient {
+				out, err := normalizeLenient(child.data)
+				if err != nil {
+					child.path = nil
+					child.data = nil
+					child.Release()
+					return nil, err
+				}
+				child.data = out
+				child.length = len(out)
+				child.lenient = true
+				child.lenientRead = true
+			}
+			return child, nil
+		case 'n':
+			dec.cursor++
+			err := dec.assertNull()
+			if err != nil {
+				return nil, err
+			}
+			dec.cursor++
+			dec.called |= 1
+			return nil, nil
+		default:
+			err := dec.mismatch(InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to JSON string, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			))
+			if err != nil {
+				return nil, err
+			}
+			dec.called |= 1
+			return nil, nil
+		}
+	}
+	return nil, InvalidJSONError("Invalid JSON while parsing string")
+}
+
+// endStringDecoder reports the errors of a decoder returned by stringDecoder and releases it.
+func (dec *Decoder) endStringDecoder(child *Decoder, err error) error {
+	// the path and data are shared with the parent, detach them before the child goes back to the pool
+	child.path = nil
+	child.data = nil
+	defer child.Release()
+	if child.err != nil {
+		if dec.policy == CollectAll {
+			dec.errs = append(dec.errs, child.errs...)
+			dec.err = dec.errs
+		} else {
+			dec.err = child.err
+		}
+	}
+	return err
+}
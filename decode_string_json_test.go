@@ -0,0 +1,138 @@
+package gojay
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnvelope struct {
+	id    int
+	body  *TestObj
+	items testSliceStrings
+	after int
+}
+
+func (t *testEnvelope) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "id":
+		return dec.AddInt(&t.id)
+	case "body":
+		t.body = &TestObj{}
+		return dec.AddObjectFromString(t.body)
+	case "items":
+		return dec.AddArrayFromString(&t.items)
+	case "after":
+		return dec.AddInt(&t.after)
+	}
+	return nil
+}
+
+func (t *testEnvelope) NKeys() int {
+	return 0
+}
+
+func TestDecoderFromString(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		json := `{
+			"id": 1,
+			"body": "{\"test\": 2, \"test3\": \"a\\\"b\", \"testSubObj\": {\"test\": 3}}",
+			"items": "[\"x\", \"y\"]",
+			"after": 4
+		}`
+		v := &testEnvelope{}
+		dec := NewDecoder(strings.NewReader(json))
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Nil(t, dec.err, "dec.err should be nil")
+		assert.Equal(t, 1, v.id, "v.id should be 1")
+		assert.Equal(t, 2, v.body.test, "v.body.test should be 2")
+		assert.Equal(t, `a"b`, v.body.test3, "v.body.test3 is not expected value")
+		assert.Equal(t, 3, v.body.testSubObj.test3, "v.body.testSubObj.test3 should be 3")
+		assert.Equal(t, testSliceStrings{"x", "y"}, v.items, "v.items is not expected value")
+		assert.Equal(t, 4, v.after, "v.after should be 4")
+	})
+	t.Run("null", func(t *testing.T) {
+		v := &testEnvelope{}
+		err := Unmarshal([]byte(`{"body": null, "items": null, "after": 4}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 0, v.body.test, "v.body.test should be 0")
+		assert.Equal(t, 4, v.after, "v.after should be 4")
+	})
+	t.Run("invalid-type", func(t *testing.T) {
+		v := &testEnvelope{}
+		err := Unmarshal([]byte(`{"body": {"test": 1}, "after": 4}`), v)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Equal(t, 4, v.after, "v.after should be 4")
+	})
+	t.Run("inner-mismatch-collected", func(t *testing.T) {
+		v := &testEnvelope{}
+		dec := NewDecoder(strings.NewReader(`{"id": "x", "body": "{\"test\": \"str\", \"test2\": 2}", "after": 4}`))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(v)
+		assert.IsType(t, MultiError{}, err, "err should be of type MultiError")
+		errs := err.(MultiError)
+		assert.Len(t, errs, 2, "errs should be of len 2")
+		assert.Equal(t, "$.id", errs[0].(MismatchError).Path, "path is not expected value")
+		assert.Equal(t, "$.body.test", errs[1].(MismatchError).Path, "path is not expected value")
+		assert.Equal(t, 2, v.body.test2, "v.body.test2 should be 2")
+		assert.Equal(t, 4, v.after, "v.after should be 4")
+	})
+	t.Run("inner-invalid-json", func(t *testing.T) {
+		v := &testEnvelope{}
+		err := Unmarshal([]byte(`{"body": "{\"test\": 1", "after": 4}`), v)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+	t.Run("inherited-options", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var child *Decoder
+		var n int
+		var ctxInherited, nonFiniteInherited bool
+		dec := NewDecoderContext(ctx, strings.NewReader(`{"body": "{\"n\": \"1\"}"}`))
+		dec.SetErrorPolicy(FailFast)
+		dec.SetAllowNonFinite(true)
+		dec.SetQuotedValues(true)
+		err := dec.DecodeObject(DecodeObjectFunc(func(dec *Decoder, k string) error {
+			return dec.AddObjectFromString(DecodeObjectFunc(func(dec *Decoder, k string) error {
+				child = dec
+				ctxInherited = dec.ctx == ctx
+				nonFiniteInherited = dec.allowNonFinite
+				assert.Equal(t, FailFast, dec.policy, "dec.policy should be inherited")
+				assert.Equal(t, "$.body.n", dec.pathString(), "dec.path should be inherited")
+				return dec.AddInt(&n)
+			}))
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 1, n, "quoted values should be decoded by the child")
+		assert.True(t, ctxInherited, "ctx should be inherited")
+		assert.True(t, nonFiniteInherited, "allowNonFinite should be inherited")
+		assert.Nil(t, child.data, "child.data should be cleared before release")
+	})
+	t.Run("lenient", func(t *testing.T) {
+		v := &testEnvelope{}
+		dec := NewDecoder(strings.NewReader(`{body: "{test: 0x2, /* c */ test3: 'a',}", after: 4,}`))
+		dec.SetLenient(true)
+		err := dec.DecodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 2, v.body.test, "v.body.test should be 2")
+		assert.Equal(t, "a", v.body.test3, "v.body.test3 should be a")
+		assert.Equal(t, 4, v.after, "v.after should be 4")
+	})
+	t.Run("round-trip", func(t *testing.T) {
+		b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddObjectAsStringKey("body", EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddIntKey("test", 5)
+				enc.AddStringKey("test3", `q"q`)
+			}))
+		}))
+		assert.Nil(t, err, "err should be nil")
+		v := &testEnvelope{}
+		err = Unmarshal(b, v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 5, v.body.test, "v.body.test should be 5")
+		assert.Equal(t, `q"q`, v.body.test3, "v.body.test3 is not expected value")
+	})
+}
@@ -0,0 +1,68 @@
+package gojay
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// AddTextUnmarshaler decodes the next key, a JSON string, to v using its UnmarshalText method.
+// It is meant for types like net.IP or enums implementing encoding.TextUnmarshaler.
+// If next key is null, v is left untouched.
+// If UnmarshalText returns an error, it is reported according to the decoder's error policy.
+func (dec *Decoder) AddTextUnmarshaler(v encoding.TextUnmarshaler) error {
+	err := dec.decodeTextUnmarshaler(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+func (dec *Decoder) decodeTextUnmarshaler(v encoding.TextUnmarshaler) error {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '"':
+			pos := dec.cursor
+			dec.cursor = dec.cursor + 1
+			start, end, err := dec.getString()
+			if err != nil {
+				return err
+			}
+			// we do minus one to remove the last quote
+			err = v.UnmarshalText(dec.data[start : end-1])
+			if err != nil {
+				dec.cursor = pos
+				dec.recordMismatch(err)
+				dec.cursor = end
+				if dec.failed() {
+					return dec.err
+				}
+			}
+			return nil
+		// is nil
+		case 'n':
+			dec.cursor++
+			err := dec.assertNull()
+			if err != nil {
+				return err
+			}
+			dec.cursor++
+			return nil
+		default:
+			err := dec.mismatch(InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to text, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			))
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+	return InvalidJSONError("Invalid JSON while parsing string")
+}
@@ -0,0 +1,114 @@
+package gojay
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel int
+
+const (
+	testLevelDebug testLevel = iota
+	testLevelInfo
+	testLevelError
+)
+
+var testLevelNames = []string{"debug", "info", "error"}
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	if int(l) < 0 || int(l) >= len(testLevelNames) {
+		return nil, errors.New("unknown level")
+	}
+	return []byte(testLevelNames[l]), nil
+}
+
+func (l *testLevel) UnmarshalText(b []byte) error {
+	for i, n := range testLevelNames {
+		if n == string(b) {
+			*l = testLevel(i)
+			return nil
+		}
+	}
+	return errors.New("unknown level " + string(b))
+}
+
+type testTextObj struct {
+	level testLevel
+	ip    net.IP
+	n     int
+}
+
+func (o *testTextObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "level":
+		return dec.AddTextUnmarshaler(&o.level)
+	case "ip":
+		return dec.AddTextUnmarshaler(&o.ip)
+	case "n":
+		return dec.AddInt(&o.n)
+	}
+	return nil
+}
+
+func (o *testTextObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderTextUnmarshaler(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		v := &testTextObj{}
+		err := UnmarshalObject([]byte(`{"level":"error","ip":"192.168.0.1","n":1}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, testLevelError, v.level, "v.level is not expected value")
+		assert.Equal(t, "192.168.0.1", v.ip.String(), "v.ip is not expected value")
+		assert.Equal(t, 1, v.n, "v.n is not expected value")
+	})
+	t.Run("null", func(t *testing.T) {
+		v := &testTextObj{level: testLevelInfo}
+		err := UnmarshalObject([]byte(`{"level":null,"n":1}`), v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, testLevelInfo, v.level, "v.level should be untouched")
+		assert.Equal(t, 1, v.n, "v.n is not expected value")
+	})
+	t.Run("unmarshal-text-error", func(t *testing.T) {
+		v := &testTextObj{}
+		dec := NewDecoder(strings.NewReader(`{"level":"fatal","n":1}`))
+		dec.SetErrorPolicy(CollectAll)
+		err := dec.DecodeObject(v)
+		assert.Len(t, err, 1, "error should be collected")
+		assert.Equal(t, "$.level", err.(MultiError)[0].(MismatchError).Path, "path is not expected value")
+		assert.Equal(t, 9, err.(MultiError)[0].(MismatchError).Pos, "pos is not expected value")
+		assert.Equal(t, 1, v.n, "v.n is not expected value")
+	})
+	t.Run("wrong-type", func(t *testing.T) {
+		v := &testTextObj{}
+		err := UnmarshalObject([]byte(`{"level":1,"n":1}`), v)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Equal(t, 1, v.n, "v.n is not expected value")
+	})
+	t.Run("unmarshal-api", func(t *testing.T) {
+		var l testLevel
+		err := Unmarshal([]byte(`"info"`), &l)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, testLevelInfo, l, "l is not expected value")
+		var ip net.IP
+		err = Unsafe.Unmarshal([]byte(`"::1"`), &ip)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "::1", ip.String(), "ip is not expected value")
+	})
+	t.Run("decode-api", func(t *testing.T) {
+		var l testLevel
+		dec := NewDecoder(strings.NewReader(`"debug"`))
+		err := dec.Decode(&l)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, testLevelDebug, l, "l is not expected value")
+		dec = NewDecoder(strings.NewReader(`"fatal"`))
+		err = dec.Decode(&l)
+		assert.Nil(t, err, "err should be nil")
+		assert.NotNil(t, dec.err, "dec.err should not be nil")
+	})
+}
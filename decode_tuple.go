@@ -0,0 +1,116 @@
+package gojay
+
+import "fmt"
+
+// UnmarshalerTuple is the interface to implement for a struct to be decoded
+// from a JSON array where each element is identified by its position, like [lat, lng]
+// or ["op", {...}].
+//
+// UnmarshalTuple is called for each element with its 0-based index.
+// Arity returns the expected number of elements, if it is greater than 0
+// elements past the arity are skipped and a TupleArityError is reported
+// when the number of elements differs, according to the decoder's error policy.
+// Elements not decoded by UnmarshalTuple are skipped.
+type UnmarshalerTuple interface {
+	UnmarshalTuple(*Decoder, int) error
+	Arity() int
+}
+
+// DecodeTuple reads the next JSON-encoded value from its input and stores it in the value pointed to by v.
+//
+// v must implement UnmarshalerTuple.
+//
+// See the documentation for Unmarshal for details about the conversion of JSON into a Go value.
+func (dec *Decoder) DecodeTuple(v UnmarshalerTuple) error {
+	if dec.isPooled == 1 {
+		panic(InvalidUsagePooledDecoderError("Invalid usage of pooled decoder"))
+	}
+	dec.resetPolicy()
+	_, err := dec.decodeTuple(v)
+	return dec.policyErr(err)
+}
+
+func (dec *Decoder) decodeTuple(v UnmarshalerTuple) (int, error) {
+	arity := v.Arity()
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '[':
+			n := 0
+			depth := len(dec.path)
+			dec.cursor = dec.cursor + 1
+			for dec.nextChar() != 0 {
+				// closing tuple
+				if dec.data[dec.cursor] == ']' {
+					if arity > 0 && n != arity {
+						dec.path = dec.path[:depth]
+						dec.recordMismatch(TupleArityError{Expected: arity, Got: n})
+						if dec.failed() {
+							return 0, dec.err
+						}
+					}
+					dec.cursor = dec.cursor + 1
+					return dec.cursor, nil
+				}
+				if dec.tracksPath() {
+					dec.path = append(dec.path[:depth], pathElem{index: n})
+				}
+				dec.called &= 0
+				if arity == 0 || n < arity {
+					err := v.UnmarshalTuple(dec, n)
+					if err != nil {
+						return 0, err
+					} else if dec.failed() {
+						return 0, dec.err
+					}
+				}
+				if dec.called&1 == 0 {
+					err := dec.skipData()
+					if err != nil {
+						return 0, err
+					}
+				}
+				n++
+			}
+			return 0, InvalidJSONError("Invalid JSON could not find array closing bracket")
+		case 'n':
+			// is null
+			dec.cursor++
+			err := dec.assertNull()
+			if err != nil {
+				return 0, err
+			}
+			dec.cursor++
+			return dec.cursor, nil
+		case '{', '"', 'f', 't', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			// can't unmarshall to tuple
+			// we skip value and set Error
+			err := dec.mismatch(InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to tuple, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			))
+			if err != nil {
+				return 0, err
+			}
+			return dec.cursor, nil
+		default:
+			return 0, InvalidJSONError("Invalid JSON")
+		}
+	}
+	return 0, InvalidJSONError("Invalid JSON")
+}
+
+// AddTuple decodes the next key to a UnmarshalerTuple.
+func (dec *Decoder) AddTuple(v UnmarshalerTuple) error {
+	newCursor, err := dec.decodeTuple(v)
+	if err != nil {
+		return err
+	}
+	dec.cursor = newCursor
+	dec.called |= 1
+	return nil
+}
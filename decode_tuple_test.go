@@ -0,0 +1,144 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPoint struct {
+	lat float64
+	lng float64
+}
+
+func (p *testPoint) UnmarshalTuple(dec *Decoder, i int) error {
+	switch i {
+	case 0:
+		return dec.AddFloat(&p.lat)
+	case 1:
+		return dec.AddFloat(&p.lng)
+	}
+	return nil
+}
+
+func (p *testPoint) Arity() int {
+	return 2
+}
+
+type testOp struct {
+	op   string
+	args *TestObj
+	n    int
+}
+
+func (o *testOp) UnmarshalTuple(dec *Decoder, i int) error {
+	switch i {
+	case 0:
+		return dec.AddString(&o.op)
+	case 1:
+		o.args = &TestObj{}
+		return dec.AddObject(o.args)
+	case 3:
+		return dec.AddInt(&o.n)
+	}
+	return nil
+}
+
+func (o *testOp) Arity() int {
+	return 0
+}
+
+type testPoints []*testPoint
+
+func (t *testPoints) UnmarshalArray(dec *Decoder) error {
+	p := &testPoint{}
+	*t = append(*t, p)
+	return dec.AddTuple(p)
+}
+
+type testRoute struct {
+	name   string
+	points testPoints
+}
+
+func (r *testRoute) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "name":
+		return dec.AddString(&r.name)
+	case "points":
+		return dec.AddArray(&r.points)
+	}
+	return nil
+}
+
+func (r *testRoute) NKeys() int {
+	return 0
+}
+
+func TestDecodeTuple(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		p := &testPoint{}
+		err := Unmarshal([]byte(` [48.85, 2.35] `), p)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 48.85, p.lat, "p.lat should be 48.85")
+		assert.Equal(t, 2.35, p.lng, "p.lng should be 2.35")
+	})
+	t.Run("heterogeneous", func(t *testing.T) {
+		o := &testOp{}
+		dec := NewDecoder(strings.NewReader(`["update", {"test": 1, "test3": "str"}, [1, {"skip": true}], 3]`))
+		err := dec.DecodeTuple(o)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "update", o.op, "o.op should be update")
+		assert.Equal(t, 1, o.args.test, "o.args.test should be 1")
+		assert.Equal(t, "str", o.args.test3, "o.args.test3 should be str")
+		assert.Equal(t, 3, o.n, "o.n should be 3")
+	})
+	t.Run("nested", func(t *testing.T) {
+		r := &testRoute{}
+		err := Unsafe.Unmarshal([]byte(`{"name": "route", "points": [[1.5, 2.5], [3.5, 4.5]]}`), r)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "route", r.name, "r.name should be route")
+		assert.Len(t, r.points, 2, "r.points should be of len 2")
+		assert.Equal(t, 4.5, r.points[1].lng, "r.points[1].lng should be 4.5")
+	})
+	t.Run("null", func(t *testing.T) {
+		p := &testPoint{}
+		dec := NewDecoder(strings.NewReader(`null`))
+		err := dec.Decode(p)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 0.0, p.lat, "p.lat should be 0")
+	})
+	t.Run("arity-too-few", func(t *testing.T) {
+		p := &testPoint{}
+		err := Unmarshal([]byte(`[1.5]`), p)
+		assert.IsType(t, TupleArityError{}, err, "err should be of type TupleArityError")
+		assert.Equal(t, TupleArityError{Expected: 2, Got: 1}, err, "err is not expected value")
+	})
+	t.Run("arity-too-many", func(t *testing.T) {
+		p := &testPoint{}
+		err := Unmarshal([]byte(`[1.5, 2.5, {"a": [1]}]`), p)
+		assert.Equal(t, TupleArityError{Expected: 2, Got: 3}, err, "err is not expected value")
+		assert.Equal(t, 2.5, p.lng, "p.lng should be 2.5")
+	})
+	t.Run("arity-fail-fast", func(t *testing.T) {
+		r := &testRoute{}
+		dec := NewDecoder(strings.NewReader(`{"points": [[1.5, 2.5], [3.5]], "name": "route"}`))
+		dec.SetErrorPolicy(FailFast)
+		err := dec.DecodeObject(r)
+		assert.IsType(t, MismatchError{}, err, "err should be of type MismatchError")
+		assert.Equal(t, "$.points[1]", err.(MismatchError).Path, "err.Path is not expected value")
+		assert.Equal(t, TupleArityError{Expected: 2, Got: 1}, err.(MismatchError).Err, "err.Err is not expected value")
+		assert.Equal(t, "", r.name, "decoding should have stopped before name")
+	})
+	t.Run("invalid-type", func(t *testing.T) {
+		p := &testPoint{}
+		err := Unmarshal([]byte(`{"lat": 1}`), p)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	})
+	t.Run("invalid-json", func(t *testing.T) {
+		p := &testPoint{}
+		err := Unmarshal([]byte(`[1.5, 2.5`), p)
+		assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	})
+}
@@ -1,6 +1,9 @@
 package gojay
 
 import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
@@ -87,16 +90,41 @@ func (u decUnsafe) Unmarshal(data []byte, v interface{}) error {
 		dec.length = len(data)
 		dec.data = data
 		err = dec.decodeBool(vt)
+	case UnmarshalerObjectIndex:
+		dec = borrowDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		_, err = dec.decodeObjectIndex(vt)
 	case UnmarshalerObject:
 		dec = borrowDecoder(nil, 0)
 		dec.length = len(data)
 		dec.data = data
 		_, err = dec.decodeObject(vt)
+	case UnmarshalerTuple:
+		dec = borrowDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		_, err = dec.decodeTuple(vt)
 	case UnmarshalerArray:
 		dec = borrowDecoder(nil, 0)
 		dec.length = len(data)
 		dec.data = data
 		_, err = dec.decodeArray(vt)
+	case *sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		dec = borrowDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		err = dec.decodeSQLNull(vt)
+	case json.Unmarshaler:
+		dec = borrowDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		err = dec.decodeJSONUnmarshaler(vt)
+	case encoding.TextUnmarshaler:
+		dec = borrowDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		err = dec.decodeTextUnmarshaler(vt)
 	default:
 		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(vt).String()))
 	}
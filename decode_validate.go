@@ -0,0 +1,243 @@
+package gojay
+
+import "fmt"
+
+// validate checks that the input holds a well-formed JSON value starting at the cursor,
+// reading more input if needed. It doesn't consume the input and returns the position right after the value.
+//
+// Contrary to the decoding methods, validation is strict: commas are only accepted between values.
+// NaN and Infinity are accepted if the decoder allows them.
+func (dec *Decoder) validate() (int, error) {
+	// closing brackets of the containers being validated
+	stack := make([]byte, 0, 16)
+	i := dec.cursor
+	var err error
+	for {
+		// a value is expected at i
+		i = dec.validateSpace(i)
+		if !dec.has(i) {
+			return 0, dec.validateEOF()
+		}
+		switch c := dec.data[i]; c {
+		case '{':
+			i = dec.validateSpace(i + 1)
+			if dec.has(i) && dec.data[i] == '}' {
+				i++
+				break
+			}
+			stack = append(stack, '}')
+			if i, err = dec.validateKey(i); err != nil {
+				return 0, err
+			}
+			continue
+		case '[':
+			i = dec.validateSpace(i + 1)
+			if dec.has(i) && dec.data[i] == ']' {
+				i++
+				break
+			}
+			stack = append(stack, ']')
+			continue
+		case '"':
+			i, err = dec.validateString(i)
+		case 't':
+			i, err = dec.validateLiteral(i, "true")
+		case 'f':
+			i, err = dec.validateLiteral(i, "false")
+		case 'n':
+			i, err = dec.validateLiteral(i, "null")
+		case 'N':
+			if !dec.allowNonFinite {
+				return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+			}
+			i, err = dec.validateLiteral(i, "NaN")
+		case 'I':
+			if !dec.allowNonFinite {
+				return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+			}
+			i, err = dec.validateLiteral(i, "Infinity")
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			if c == '-' && dec.allowNonFinite && dec.has(i+1) && dec.data[i+1] == 'I' {
+				i, err = dec.validateLiteral(i+1, "Infinity")
+				break
+			}
+			i, err = dec.validateNumber(i)
+		default:
+			return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+		}
+		if err != nil {
+			return 0, err
+		}
+		// a value ended at i, close containers until a comma is found
+		for {
+			if len(stack) == 0 {
+				return i, nil
+			}
+			i = dec.validateSpace(i)
+			if !dec.has(i) {
+				return 0, dec.validateEOF()
+			}
+			c := dec.data[i]
+			if c == stack[len(stack)-1] {
+				stack = stack[:len(stack)-1]
+				i++
+				continue
+			}
+			if c != ',' {
+				return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+			}
+			i++
+			if stack[len(stack)-1] == '}' {
+				if i, err = dec.validateKey(i); err != nil {
+					return 0, err
+				}
+			}
+			break
+		}
+	}
+}
+
+// has reports whether the input has a char at position i, reading more input if needed.
+func (dec *Decoder) has(i int) bool {
+	for i >= dec.length {
+		if !dec.read() {
+			return false
+		}
+	}
+	return true
+}
+
+func (dec *Decoder) validateEOF() error {
+	return InvalidJSONError("Invalid JSON, unexpected end of input")
+}
+
+func (dec *Decoder) validateSpace(i int) int {
+	for dec.has(i) {
+		switch dec.data[i] {
+		case ' ', '\n', '\t', '\r':
+			i++
+			continue
+		}
+		break
+	}
+	return i
+}
+
+// validateKey validates an object key and the colon following it.
+func (dec *Decoder) validateKey(i int) (int, error) {
+	i = dec.validateSpace(i)
+	if !dec.has(i) {
+		return 0, dec.validateEOF()
+	}
+	if dec.data[i] != '"' {
+		return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+	}
+	i, err := dec.validateString(i)
+	if err != nil {
+		return 0, err
+	}
+	i = dec.validateSpace(i)
+	if !dec.has(i) {
+		return 0, dec.validateEOF()
+	}
+	if dec.data[i] != ':' {
+		return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+	}
+	return i + 1, nil
+}
+
+func (dec *Decoder) validateString(i int) (int, error) {
+	for i++; dec.has(i); i++ {
+		switch c := dec.data[i]; {
+		case c == '"':
+			return i + 1, nil
+		case c == '\\':
+			i++
+			if !dec.has(i) {
+				return 0, dec.validateEOF()
+			}
+			switch dec.data[i] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				for j := 0; j < 4; j++ {
+					i++
+					if !dec.has(i) {
+						return 0, dec.validateEOF()
+					}
+					if !isHexDigit(dec.data[i]) {
+						return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+					}
+				}
+			default:
+				return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+			}
+		case c < 0x20:
+			return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, c, i))
+		}
+	}
+	return 0, dec.validateEOF()
+}
+
+func (dec *Decoder) validateLiteral(i int, lit string) (int, error) {
+	for j := 0; j < len(lit); j++ {
+		if !dec.has(i + j) {
+			return 0, dec.validateEOF()
+		}
+		if dec.data[i+j] != lit[j] {
+			return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i+j], i+j))
+		}
+	}
+	return i + len(lit), nil
+}
+
+func (dec *Decoder) validateNumber(i int) (int, error) {
+	if dec.data[i] == '-' {
+		i++
+	}
+	// integer part, no leading zero
+	if !dec.has(i) {
+		return 0, dec.validateEOF()
+	}
+	if dec.data[i] == '0' {
+		i++
+	} else if n := dec.validateDigits(i); n > i {
+		i = n
+	} else {
+		return 0, InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+	}
+	// fraction
+	if dec.has(i) && dec.data[i] == '.' {
+		n := dec.validateDigits(i + 1)
+		if n == i+1 {
+			return 0, dec.validateNumberErr(n)
+		}
+		i = n
+	}
+	// exponent
+	if dec.has(i) && (dec.data[i] == 'e' || dec.data[i] == 'E') {
+		i++
+		if dec.has(i) && (dec.data[i] == '+' || dec.data[i] == '-') {
+			i++
+		}
+		n := dec.validateDigits(i)
+		if n == i {
+			return 0, dec.validateNumberErr(n)
+		}
+		i = n
+	}
+	return i, nil
+}
+
+func (dec *Decoder) validateDigits(i int) int {
+	for dec.has(i) && dec.data[i] >= '0' && dec.data[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+func (dec *Decoder) validateNumberErr(i int) error {
+	if !dec.has(i) {
+		return dec.validateEOF()
+	}
+	return InvalidJSONError(fmt.Sprintf(invalidJSONCharErrorMsg, dec.data[i], i))
+}
@@ -1,9 +1,10 @@
 package gojay
 
 import (
-	"fmt"
+	"database/sql"
+	"encoding"
+	"encoding/json"
 	"io"
-	"reflect"
 )
 
 // MarshalObject returns the JSON encoding of v.
@@ -32,7 +33,8 @@ func MarshalObject(v MarshalerObject) ([]byte, error) {
 	enc := BorrowEncoder(nil)
 	enc.grow(512)
 	defer enc.Release()
-	return enc.encodeObject(v)
+	b, err := enc.encodeObject(v)
+	return copyBytes(b), err
 }
 
 // MarshalArray returns the JSON encoding of v.
@@ -59,11 +61,9 @@ func MarshalObject(v MarshalerObject) ([]byte, error) {
 func MarshalArray(v MarshalerArray) ([]byte, error) {
 	enc := BorrowEncoder(nil)
 	enc.grow(512)
-	enc.writeByte('[')
-	v.(MarshalerArray).MarshalArray(enc)
-	enc.writeByte(']')
 	defer enc.Release()
-	return enc.buf, nil
+	b, err := enc.encodeArray(v)
+	return copyBytes(b), err
 }
 
 // Marshal returns the JSON encoding of v.
@@ -76,8 +76,15 @@ func MarshalArray(v MarshalerArray) ([]byte, error) {
 // If v implements Marshaler or Marshaler interface
 // it will call the corresponding methods.
 //
+// The database/sql null types are encoded as their value, or null if they are not valid.
+//
+// Other types implementing json.Marshaler are encoded with their MarshalJSON method,
+// and types implementing encoding.TextMarshaler are encoded as JSON strings.
+//
 // If a struct, slice, or array is passed and does not implement these interfaces
 // it will return a a non nil InvalidTypeError error.
+//
+// The returned slice is owned by the caller, use MarshalTo to append to an existing buffer instead.
 // Example with an Marshaler:
 //	type TestStruct struct {
 //		id int
@@ -97,73 +104,57 @@ func MarshalArray(v MarshalerArray) ([]byte, error) {
 // 		fmt.Println(b) // {"id":123456}
 //	}
 func Marshal(v interface{}) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	defer enc.Release()
+	b, err := enc.marshal(v)
+	return copyBytes(b), err
+}
+
+// marshal encodes v to the buffer according to its type, see Marshal.
+func (enc *Encoder) marshal(v interface{}) ([]byte, error) {
 	switch vt := v.(type) {
 	case MarshalerObject:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeObject(vt)
 	case MarshalerArray:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeArray(vt)
 	case string:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeString(vt)
 	case bool:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeBool(vt)
 	case int:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeInt(vt)
 	case int64:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeInt64(vt)
 	case int32:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeInt32(vt)
 	case int16:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeInt16(vt)
 	case int8:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeInt8(vt)
 	case uint64:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeUint64(vt)
 	case uint32:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeUint32(vt)
 	case uint16:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeUint16(vt)
 	case uint8:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
-		return enc.encodeInt(int(vt))
+		return enc.encodeUint8(vt)
 	case float64:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeFloat(vt)
 	case float32:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeFloat32(vt)
 	case *EmbeddedJSON:
-		enc := BorrowEncoder(nil)
-		defer enc.Release()
 		return enc.encodeEmbeddedJSON(vt)
+	case sql.NullString, sql.NullInt64, sql.NullFloat64, sql.NullBool, sql.NullTime,
+		*sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		return enc.encodeSQLNull(vt)
+	case json.Marshaler:
+		return enc.encodeJSONMarshaler(vt)
+	case encoding.TextMarshaler:
+		return enc.encodeTextMarshaler(vt)
 	default:
-		return nil, InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, reflect.TypeOf(vt).String()))
+		enc.writeInterface(vt)
+		return enc.buf, enc.err
 	}
 }
 
@@ -182,10 +173,36 @@ type MarshalerArray interface {
 
 // An Encoder writes JSON values to an output stream.
 type Encoder struct {
-	buf      []byte
-	isPooled byte
-	w        io.Writer
-	err      error
+	buf       []byte
+	isPooled  byte
+	w         io.Writer
+	err       error
+	nonFinite NonFinitePolicy
+	// quoteInt64 writes 64-bit integers as JSON strings
+	quoteInt64 bool
+	// escapeHTML escapes <, > and & in strings
+	escapeHTML bool
+	// asciiOnly escapes non ASCII characters in strings
+	asciiOnly bool
+	// pretty writes each element on its own line, starting with prefix and depth times indent
+	pretty bool
+	prefix string
+	indent string
+	// depth is the number of open objects and arrays
+	depth int
+	// flushThreshold is the buffer size above which the buffer is written to w between two values, 0 disables it
+	flushThreshold int
+	// written is the number of bytes written to w
+	written int
+	// last is the last byte written to w, it is the previous rune when the buffer is empty
+	last byte
+	// floatFmt and floatPrec are the strconv format and precision of floats, 0 is the encoding/json format
+	floatFmt  byte
+	floatPrec int
+	// ptrLevel is the number of maps, slices and pointers being written by writeInterface,
+	// ptrSeen holds those being written once ptrLevel is above startDetectingCyclesAfter
+	ptrLevel int
+	ptrSeen  map[ptrKey]struct{}
 }
 
 // AppendBytes allows a modular usage by appending bytes manually to the current state of the buffer.
@@ -204,13 +221,37 @@ func (enc *Encoder) Buf() []byte {
 }
 
 // Write writes to the io.Writer and resets the buffer.
+//
+// If the io.Writer writes only part of the buffer, the bytes not written are kept in the buffer
+// and the error is returned, io.ErrShortWrite if the io.Writer returned none.
+// If no io.Writer was given, a NoWriterError is returned.
 func (enc *Encoder) Write() (int, error) {
+	if enc.w == nil {
+		return 0, NoWriterError("No writer given to encode")
+	}
 	i, err := enc.w.Write(enc.buf)
+	if i > 0 {
+		enc.written += i
+		enc.last = enc.buf[i-1]
+	}
+	if i < len(enc.buf) {
+		if err == nil {
+			err = io.ErrShortWrite
+		}
+		if i > 0 {
+			enc.buf = enc.buf[:copy(enc.buf, enc.buf[i:])]
+		}
+		return i, err
+	}
 	enc.buf = enc.buf[:0]
 	return i, err
 }
 
 func (enc *Encoder) getPreviousRune() byte {
 	last := len(enc.buf) - 1
+	if last < 0 {
+		// the buffer has been flushed
+		return enc.last
+	}
 	return enc.buf[last]
 }
@@ -0,0 +1,70 @@
+package gojay
+
+// AppendMarshalObject appends the JSON encoding of v to dst and returns the extended buffer.
+// If encoding fails, dst is returned unchanged with the error.
+//
+// It doesn't allocate if dst has enough capacity, see MarshalObject.
+func AppendMarshalObject(dst []byte, v MarshalerObject) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	defer enc.Release()
+	buf := enc.buf
+	enc.buf = dst
+	_, err := enc.encodeObject(v)
+	b := enc.buf
+	// the pooled encoder must not keep a reference to the caller's buffer
+	enc.buf = buf
+	if err != nil {
+		// drop the partial output, it may have been written to the spare capacity of dst
+		return dst, err
+	}
+	return b, nil
+}
+
+// AppendMarshalArray appends the JSON encoding of v to dst and returns the extended buffer.
+// If encoding fails, dst is returned unchanged with the error.
+//
+// It doesn't allocate if dst has enough capacity, see MarshalArray.
+func AppendMarshalArray(dst []byte, v MarshalerArray) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	defer enc.Release()
+	buf := enc.buf
+	enc.buf = dst
+	_, err := enc.encodeArray(v)
+	b := enc.buf
+	enc.buf = buf
+	if err != nil {
+		// drop the partial output, it may have been written to the spare capacity of dst
+		return dst, err
+	}
+	return b, nil
+}
+
+// MarshalTo appends the JSON encoding of v to dst and returns the extended buffer.
+// If encoding fails, dst is returned unchanged with the error.
+//
+// It doesn't allocate if dst has enough capacity, see Marshal for details about the conversion of Go value to JSON.
+func MarshalTo(dst []byte, v interface{}) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	defer enc.Release()
+	buf := enc.buf
+	enc.buf = dst
+	_, err := enc.marshal(v)
+	b := enc.buf
+	enc.buf = buf
+	if err != nil {
+		// drop the partial output, it may have been written to the spare capacity of dst
+		return dst, err
+	}
+	return b, nil
+}
+
+// copyBytes returns a copy of b, so that the caller owns the bytes returned
+// once the encoder holding b is released.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,165 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalOwnership(t *testing.T) {
+	testCases := []struct {
+		name    string
+		marshal func(i int) ([]byte, error)
+	}{
+		{
+			name: "marshal-object",
+			marshal: func(i int) ([]byte, error) {
+				return MarshalObject(&SubObject{test1: i})
+			},
+		},
+		{
+			name: "marshal-array",
+			marshal: func(i int) ([]byte, error) {
+				return MarshalArray(TestEncodingArrStrings{string(rune('a' + i%26))})
+			},
+		},
+		{
+			name: "marshal",
+			marshal: func(i int) ([]byte, error) {
+				return Marshal(i)
+			},
+		},
+		{
+			name: "marshal-object-indent",
+			marshal: func(i int) ([]byte, error) {
+				return MarshalObjectIndent(&SubObject{test1: i}, "", " ")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results := make([][]byte, 100)
+			expected := make([]string, 100)
+			for i := range results {
+				b, err := testCase.marshal(i)
+				assert.Nil(t, err, "err should be nil")
+				results[i] = b
+				expected[i] = string(b)
+			}
+			for i := range results {
+				assert.Equal(t, expected[i], string(results[i]), "result should not be modified by later calls")
+			}
+		})
+	}
+}
+
+func TestAppendMarshal(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		dst := []byte(`data: `)
+		b, err := AppendMarshalObject(dst, &SubObject{test1: 1})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`data: {"test1":1,"test2":"","test3":0,"testBool":false,"sub":{}}`,
+			string(b),
+			"string(b) is not expected value",
+		)
+	})
+	t.Run("array", func(t *testing.T) {
+		b, err := AppendMarshalArray(nil, TestEncodingArrStrings{"a", "b"})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `["a","b"]`, string(b), "string(b) is not expected value")
+	})
+	t.Run("marshal-to", func(t *testing.T) {
+		b, err := MarshalTo([]byte(`[`), "test")
+		assert.Nil(t, err, "err should be nil")
+		b, err = MarshalTo(append(b, ','), 1.5)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `["test",1.5`, string(b), "string(b) is not expected value")
+	})
+	t.Run("marshal-to-error", func(t *testing.T) {
+		dst := []byte(`[`)
+		b, err := MarshalTo(dst, struct{}{})
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, InvalidMarshalError(""), err, "err should be of type InvalidMarshalError")
+		assert.Equal(t, `[`, string(b), "dst should be returned")
+	})
+	t.Run("error-returns-dst", func(t *testing.T) {
+		errObject := EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("a", 1)
+			enc.AddError(InvalidMarshalError("err"))
+		})
+		errArray := testErrorArray{}
+		testCases := []struct {
+			name   string
+			append func(dst []byte) ([]byte, error)
+		}{
+			{
+				name: "object",
+				append: func(dst []byte) ([]byte, error) {
+					return AppendMarshalObject(dst, errObject)
+				},
+			},
+			{
+				name: "array",
+				append: func(dst []byte) ([]byte, error) {
+					return AppendMarshalArray(dst, errArray)
+				},
+			},
+			{
+				name: "marshal-to-object",
+				append: func(dst []byte) ([]byte, error) {
+					return MarshalTo(dst, errObject)
+				},
+			},
+			{
+				name: "marshal-to-nested",
+				append: func(dst []byte) ([]byte, error) {
+					return MarshalTo(dst, map[string]interface{}{"a": 1, "b": struct{}{}})
+				},
+			},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				dst := make([]byte, 0, 512)
+				dst = append(dst, "prefix:"...)
+				b, err := testCase.append(dst)
+				assert.NotNil(t, err, "err should not be nil")
+				assert.Equal(t, "prefix:", string(b), "dst should be returned unchanged")
+				assert.Equal(t, len(dst), len(b), "len(b) should be len(dst)")
+			})
+		}
+	})
+	t.Run("pool-does-not-keep-dst", func(t *testing.T) {
+		dst := make([]byte, 0, 512)
+		b, err := AppendMarshalObject(dst, &SubObject{test1: 1})
+		assert.Nil(t, err, "err should be nil")
+		s := string(b)
+		for i := 0; i < 100; i++ {
+			_, _ = MarshalObject(&SubObject{test1: 2})
+			enc := BorrowEncoder(nil)
+			enc.writeString("overwritten")
+			enc.Release()
+		}
+		assert.Equal(t, s, string(b), "dst should not be reused by the pool")
+	})
+	t.Run("no-alloc", func(t *testing.T) {
+		v := &SubObject{test1: 1}
+		dst := make([]byte, 0, 512)
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = AppendMarshalObject(dst[:0], v)
+		})
+		assert.Equal(t, float64(0), allocs, "AppendMarshalObject should not allocate")
+	})
+}
+
+type testErrorArray struct{}
+
+func (testErrorArray) IsNil() bool {
+	return false
+}
+
+func (testErrorArray) MarshalArray(enc *Encoder) {
+	enc.AddInt(1)
+	enc.AddError(InvalidMarshalError("err"))
+}
@@ -6,101 +6,84 @@ func (enc *Encoder) EncodeArray(v MarshalerArray) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeArray(v)
-	_, err := enc.Write()
-	if err != nil {
-		enc.err = err
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 func (enc *Encoder) encodeArray(v MarshalerArray) ([]byte, error) {
 	enc.grow(200)
-	enc.writeByte('[')
+	enc.writeOpen('[')
 	v.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
 	return enc.buf, enc.err
 }
 
 // AddArray adds an implementation of MarshalerArray to be encoded, must be used inside a slice or array encoding (does not encode a key)
 // value must implement Marshaler
 func (enc *Encoder) AddArray(v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		enc.grow(3)
-		r := enc.getPreviousRune()
-		if r != '[' {
-			enc.writeByte(',')
-		}
-		enc.writeByte('[')
-		enc.writeByte(']')
+		enc.writeArraySep()
+		enc.writeOpen('[')
+		enc.writeClose(']')
 		return
 	}
 	enc.grow(100)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('[')
+	enc.writeArraySep()
+	enc.writeOpen('[')
 	v.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
 }
 
 // AddArrayOmitEmpty adds an array or slice to be encoded, must be used inside a slice or array encoding (does not encode a key)
 // value must implement Marshaler
 func (enc *Encoder) AddArrayOmitEmpty(v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		return
 	}
 	enc.grow(4)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('[')
+	enc.writeArraySep()
+	enc.writeOpen('[')
 	v.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
 }
 
 // AddArrayKey adds an array or slice to be encoded, must be used inside an object as it will encode a key
 // value must implement Marshaler
 func (enc *Encoder) AddArrayKey(key string, v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		enc.grow(2 + len(key))
-		r := enc.getPreviousRune()
-		if r != '{' {
-			enc.writeByte(',')
-		}
-		enc.writeByte('"')
-		enc.writeStringEscape(key)
-		enc.writeBytes(objKeyArr)
-		enc.writeByte(']')
+		enc.writeKey(key)
+		enc.writeOpen('[')
+		enc.writeClose(']')
 		return
 	}
 	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyArr)
+	enc.writeKey(key)
+	enc.writeOpen('[')
 	v.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
 }
 
 // AddArrayKeyOmitEmpty adds an array or slice to be encoded and skips it if it is nil.
 // Must be called inside an object as it will encode a key.
 func (enc *Encoder) AddArrayKeyOmitEmpty(key string, v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		return
 	}
 	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyArr)
+	enc.writeKey(key)
+	enc.writeOpen('[')
 	v.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
 }
@@ -88,7 +88,7 @@ func TestEncoderArrayMarshalAPI(t *testing.T) {
 				`"testArr":[],"testF64":0,"testF32":0,"testInterface":1,"sub":{"test1":10,"test2":"hello world",`+
 				`"test3":1.23543,"testBool":true,"sub":{"test1":10,"test2":"hello world",`+
 				`"test3":0,"testBool":false,"sub":{}}}},{"test":"hello world","test2":"漢字","testInt":1,`+
-				`"testBool":true,"testArr":[],"testF64":0,"testF32":0,"sub":{"test1":10,"test2":"hello world","test3":1.23543,`+
+				`"testBool":true,"testArr":[],"testF64":0,"testF32":0,"testInterface":null,"sub":{"test1":10,"test2":"hello world","test3":1.23543,`+
 				`"testBool":true,"sub":{"test1":10,"test2":"hello world","test3":0,"testBool":false,"sub":{}}}},{}]`,
 			string(r),
 			"Result of marshalling is different as the one expected")
@@ -122,7 +122,7 @@ func TestEncoderArrayMarshalAPI(t *testing.T) {
 		assert.Nil(t, err, "Error should be nil")
 		assert.Equal(
 			t,
-			`[1,1,1,1,1,1,1,1,1.31,1.31,[],[],true,false,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0,"sub":{}}]`,
+			`[1,1,1,1,1,1,1,1,1,1.31,1.31,[],[],true,false,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0,"testInterface":null,"sub":{}}]`,
 			string(r),
 			"Result of marshalling is different as the one expected")
 	})
@@ -159,7 +159,7 @@ func TestEncoderArrayEncodeAPI(t *testing.T) {
 		assert.Nil(t, err, "Error should be nil")
 		assert.Equal(
 			t,
-			`[1,1,1,1,1,1,1,1,1.31,[],true,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0,"sub":{}}]`,
+			`[1,1,1,1,1,1,1,1,1,1.31,[],true,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0,"testInterface":null,"sub":{}}]`,
 			builder.String(),
 			"Result of marshalling is different as the one expected")
 	})
@@ -8,12 +8,7 @@ func (enc *Encoder) EncodeBool(v bool) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeBool(v)
-	_, err := enc.Write()
-	if err != nil {
-		enc.err = err
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 // encodeBool encodes a bool to JSON
@@ -29,11 +24,11 @@ func (enc *Encoder) encodeBool(v bool) ([]byte, error) {
 
 // AddBool adds a bool to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddBool(v bool) {
-	enc.grow(5)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
+	enc.grow(5)
+	enc.writeArraySep()
 	if v {
 		enc.writeString("true")
 	} else {
@@ -43,43 +38,37 @@ func (enc *Encoder) AddBool(v bool) {
 
 // AddBoolOmitEmpty adds a bool to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddBoolOmitEmpty(v bool) {
+	if enc.err != nil {
+		return
+	}
 	if v == false {
 		return
 	}
 	enc.grow(5)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
+	enc.writeArraySep()
 	enc.writeString("true")
 }
 
 // AddBoolKey adds a bool to be encoded, must be used inside an object as it will encode a key.
 func (enc *Encoder) AddBoolKey(key string, value bool) {
-	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
+	enc.grow(5 + len(key))
+	enc.writeKey(key)
 	enc.buf = strconv.AppendBool(enc.buf, value)
 }
 
 // AddBoolKeyOmitEmpty adds a bool to be encoded and skips it if it is zero value.
 // Must be used inside an object as it will encode a key.
 func (enc *Encoder) AddBoolKeyOmitEmpty(key string, v bool) {
+	if enc.err != nil {
+		return
+	}
 	if v == false {
 		return
 	}
 	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
+	enc.writeKey(key)
 	enc.buf = strconv.AppendBool(enc.buf, v)
 }
@@ -1,5 +1,10 @@
 package gojay
 
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
 // grow grows b's capacity, if necessary, to guarantee space for
 // another n bytes. After grow(n), at least n bytes can be written to b
 // without another allocation. If n is negative, grow panics.
@@ -33,25 +38,69 @@ func (enc *Encoder) writeString(s string) {
 	enc.buf = append(enc.buf, s...)
 }
 
+// writeStringEscape writes s escaped to be used inside a JSON string.
+// Control characters are escaped, invalid UTF-8 is replaced by U+FFFD
+// and U+2028 and U+2029 are escaped as they are not valid in JavaScript strings.
+// HTML characters and non ASCII characters are escaped according to the encoder's options.
 func (enc *Encoder) writeStringEscape(s string) {
-	l := len(s)
-	for i := 0; i < l; i++ {
-		switch s[i] {
-		case '\\', '"':
-			enc.writeTwoBytes('\\', s[i])
-		case '\n':
-			enc.writeTwoBytes('\\', 'n')
-		case '\f':
-			enc.writeTwoBytes('\\', 'f')
-		case '\b':
-			enc.writeTwoBytes('\\', 'b')
-		case '\r':
-			enc.writeTwoBytes('\\', 'r')
-		case '\t':
-			enc.writeTwoBytes('\\', 't')
+	// safe bytes are written by chunks
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '\\' && c != '"' && !(enc.escapeHTML && (c == '<' || c == '>' || c == '&')) {
+				i++
+				continue
+			}
+			enc.writeString(s[start:i])
+			switch c {
+			case '\\', '"':
+				enc.writeTwoBytes('\\', c)
+			case '\n':
+				enc.writeTwoBytes('\\', 'n')
+			case '\f':
+				enc.writeTwoBytes('\\', 'f')
+			case '\b':
+				enc.writeTwoBytes('\\', 'b')
+			case '\r':
+				enc.writeTwoBytes('\\', 'r')
+			case '\t':
+				enc.writeTwoBytes('\\', 't')
+			default:
+				enc.writeUnicodeEscape(rune(c))
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			enc.writeString(s[start:i])
+			if enc.asciiOnly {
+				enc.writeUnicodeEscape(utf8.RuneError)
+			} else {
+				enc.writeString("\uFFFD")
+			}
+		case r == '\u2028' || r == '\u2029' || enc.asciiOnly:
+			enc.writeString(s[start:i])
+			if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+				enc.writeUnicodeEscape(r1)
+				enc.writeUnicodeEscape(r2)
+			} else {
+				enc.writeUnicodeEscape(r)
+			}
 		default:
-			enc.writeByte(s[i])
+			i += size
+			continue
 		}
+		i += size
+		start = i
 	}
+	enc.writeString(s[start:])
+}
 
+// writeUnicodeEscape writes r, which must be lower than 0x10000, as \uXXXX.
+func (enc *Encoder) writeUnicodeEscape(r rune) {
+	enc.buf = append(enc.buf, '\\', 'u', hex[r>>12&0xf], hex[r>>8&0xf], hex[r>>4&0xf], hex[r&0xf])
 }
@@ -1 +1,87 @@
 package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderWriteStringEscape(t *testing.T) {
+	testCases := []struct {
+		name         string
+		str          string
+		escapeHTML   bool
+		asciiOnly    bool
+		expectedJSON string
+	}{
+		{name: "basic", str: `a"b\c`, expectedJSON: `a\"b\\c`},
+		{name: "short-escapes", str: "\n\f\b\r\t", expectedJSON: `\n\f\b\r\t`},
+		{name: "control-chars", str: "a\x00b\x1fc\x7f", expectedJSON: `a\u0000b\u001fc` + "\x7f"},
+		{name: "invalid-utf8", str: "a\xffb\xe2\x82", expectedJSON: "a\xef\xbf\xbdb\xef\xbf\xbd\xef\xbf\xbd"},
+		{name: "line-separators", str: "a\xe2\x80\xa8b\xe2\x80\xa9", expectedJSON: `a\u2028b\u2029`},
+		{name: "utf8", str: "漢字𩸽", expectedJSON: "漢字𩸽"},
+		{name: "html-off", str: "<a>&", expectedJSON: "<a>&"},
+		{name: "html", str: "<script>&</script>", escapeHTML: true, expectedJSON: `\u003cscript\u003e\u0026\u003c/script\u003e`},
+		{name: "ascii-only", str: "é漢𩸽😁", asciiOnly: true, expectedJSON: `\u00e9\u6f22\ud867\ude3d\ud83d\ude01`},
+		{name: "ascii-only-invalid-utf8", str: "a\xff", asciiOnly: true, expectedJSON: `a\ufffd`},
+		{name: "html-ascii-only", str: "<é>", escapeHTML: true, asciiOnly: true, expectedJSON: `\u003c\u00e9\u003e`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := BorrowEncoder(nil)
+			defer enc.Release()
+			enc.SetEscapeHTML(testCase.escapeHTML)
+			enc.SetASCIIOnly(testCase.asciiOnly)
+			enc.writeStringEscape(testCase.str)
+			assert.Equal(t, testCase.expectedJSON, string(enc.buf), "enc.buf is not expected value")
+		})
+	}
+}
+
+func TestEncoderStringEscapeAPIs(t *testing.T) {
+	t.Run("keys-and-values", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetEscapeHTML(true)
+		enc.writeByte('{')
+		enc.AddStringKey("<k>", "\x01")
+		enc.AddStringKeyOmitEmpty("a\xe2\x80\xa8", "&")
+		enc.AddIntKey("\x02", 1)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"\u003ck\u003e":"\u0001","a\u2028":"\u0026","\u0002":1}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("encode-string", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetASCIIOnly(true)
+		err := enc.EncodeString("é\x00")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"\u00e9\u0000"`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("stream-add-string", func(t *testing.T) {
+		enc := Stream.BorrowEncoder(nil).LineDelimited()
+		defer enc.Release()
+		enc.AddString("a\"b\n")
+		assert.Equal(t, "\"a\\\"b\\n\"\n", string(enc.buf), "enc.buf is not expected value")
+	})
+	t.Run("pooled-encoder-reset", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		enc.SetEscapeHTML(true)
+		enc.SetASCIIOnly(true)
+		enc.Release()
+		enc = BorrowEncoder(nil)
+		defer enc.Release()
+		enc.writeStringEscape("<é>")
+		assert.Equal(t, "<é>", string(enc.buf), "options should be reset")
+	})
+}
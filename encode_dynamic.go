@@ -0,0 +1,364 @@
+package gojay
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// writeInterface writes v to the buffer according to its dynamic type,
+// it is used by the Add, Encode and Marshal functions for types without a dedicated method.
+//
+// nil is written as null. Values implementing error or fmt.Stringer are written as strings.
+// Maps, slices, arrays and pointers are written recursively, using reflection
+// if they are not of type map[string]interface{}, []interface{} or []string.
+// Map keys are sorted, they must be strings, integers or implement encoding.TextMarshaler.
+// Byte slices are written as base64 strings.
+//
+// If v contains a cycle, an UnsupportedValueError is set on the encoder.
+func (enc *Encoder) writeInterface(v interface{}) {
+	switch vt := v.(type) {
+	case nil:
+		enc.writeString("null")
+	case string:
+		enc.writeByte('"')
+		enc.writeStringEscape(vt)
+		enc.writeByte('"')
+	case bool:
+		enc.buf = strconv.AppendBool(enc.buf, vt)
+	case MarshalerObject:
+		enc.writeOpen('{')
+		if !vt.IsNil() {
+			vt.MarshalObject(enc)
+		}
+		enc.writeClose('}')
+	case MarshalerArray:
+		enc.writeOpen('[')
+		vt.MarshalArray(enc)
+		enc.writeClose(']')
+	case int:
+		_, _ = enc.encodeInt(vt)
+	case int64:
+		_, _ = enc.encodeInt64(vt)
+	case int32:
+		_, _ = enc.encodeInt32(vt)
+	case int16:
+		_, _ = enc.encodeInt16(vt)
+	case int8:
+		_, _ = enc.encodeInt8(vt)
+	case uint64:
+		_, _ = enc.encodeUint64(vt)
+	case uint32:
+		_, _ = enc.encodeUint32(vt)
+	case uint16:
+		_, _ = enc.encodeUint16(vt)
+	case uint8:
+		_, _ = enc.encodeUint8(vt)
+	case float64:
+		enc.writeFloat(vt, 64)
+	case float32:
+		enc.writeFloat(float64(vt), 32)
+	case *EmbeddedJSON:
+		if vt == nil {
+			enc.writeString("null")
+			return
+		}
+		enc.writeBytes(*vt)
+	case sql.NullString, sql.NullInt64, sql.NullFloat64, sql.NullBool, sql.NullTime,
+		*sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		_, _ = enc.encodeSQLNull(vt)
+	case json.Marshaler:
+		enc.writeJSON(vt)
+	case encoding.TextMarshaler:
+		enc.writeText(vt)
+	case error:
+		enc.writeByte('"')
+		enc.writeStringEscape(vt.Error())
+		enc.writeByte('"')
+	case fmt.Stringer:
+		enc.writeByte('"')
+		enc.writeStringEscape(vt.String())
+		enc.writeByte('"')
+	case map[string]interface{}:
+		if vt == nil {
+			enc.writeString("null")
+			return
+		}
+		rv := reflect.ValueOf(v)
+		if !enc.enterPtr(rv) {
+			return
+		}
+		keys := make([]string, 0, len(vt))
+		for k := range vt {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		enc.writeOpen('{')
+		for _, k := range keys {
+			enc.writeKey(k)
+			enc.writeInterface(vt[k])
+		}
+		enc.writeClose('}')
+		enc.leavePtr(rv)
+	case []interface{}:
+		if vt == nil {
+			enc.writeString("null")
+			return
+		}
+		rv := reflect.ValueOf(v)
+		if !enc.enterPtr(rv) {
+			return
+		}
+		enc.writeOpen('[')
+		for _, e := range vt {
+			enc.writeArraySep()
+			enc.writeInterface(e)
+		}
+		enc.writeClose(']')
+		enc.leavePtr(rv)
+	case []string:
+		if vt == nil {
+			enc.writeString("null")
+			return
+		}
+		enc.writeOpen('[')
+		for _, e := range vt {
+			enc.writeArraySep()
+			enc.writeByte('"')
+			enc.writeStringEscape(e)
+			enc.writeByte('"')
+		}
+		enc.writeClose(']')
+	default:
+		enc.writeReflect(reflect.ValueOf(vt))
+	}
+}
+
+// writeReflect writes rv to the buffer, rv is a container, a pointer
+// or a named type of a primitive kind. An InvalidMarshalError is set for other kinds.
+func (enc *Encoder) writeReflect(rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			enc.writeString("null")
+			return
+		}
+		if rv.Kind() == reflect.Interface {
+			enc.writeInterface(rv.Elem().Interface())
+			return
+		}
+		if !validKind(rv.Elem().Kind()) {
+			enc.invalidType(rv.Type())
+			return
+		}
+		if !enc.enterPtr(rv) {
+			return
+		}
+		enc.writeInterface(rv.Elem().Interface())
+		enc.leavePtr(rv)
+	case reflect.Map:
+		if rv.IsNil() {
+			enc.writeString("null")
+			return
+		}
+		if !enc.enterPtr(rv) {
+			return
+		}
+		enc.writeMap(rv)
+		enc.leavePtr(rv)
+	case reflect.Slice:
+		if rv.IsNil() {
+			enc.writeString("null")
+			return
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := rv.Bytes()
+			enc.grow(base64.StdEncoding.EncodedLen(len(b)) + 2)
+			enc.writeByte('"')
+			n := len(enc.buf)
+			enc.buf = enc.buf[:n+base64.StdEncoding.EncodedLen(len(b))]
+			base64.StdEncoding.Encode(enc.buf[n:], b)
+			enc.writeByte('"')
+			return
+		}
+		if !enc.enterPtr(rv) {
+			return
+		}
+		enc.writeList(rv)
+		enc.leavePtr(rv)
+	case reflect.Array:
+		enc.writeList(rv)
+	case reflect.String:
+		enc.writeByte('"')
+		enc.writeStringEscape(rv.String())
+		enc.writeByte('"')
+	case reflect.Bool:
+		enc.buf = strconv.AppendBool(enc.buf, rv.Bool())
+	case reflect.Int:
+		enc.writeInt(int(rv.Int()))
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		enc.buf = strconv.AppendInt(enc.buf, rv.Int(), 10)
+	case reflect.Int64:
+		enc.writeInt64(rv.Int())
+	case reflect.Uint, reflect.Uintptr:
+		enc.writeUint(uint(rv.Uint()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		enc.buf = strconv.AppendUint(enc.buf, rv.Uint(), 10)
+	case reflect.Uint64:
+		enc.writeUint64(rv.Uint())
+	case reflect.Float32:
+		enc.writeFloat(rv.Float(), 32)
+	case reflect.Float64:
+		enc.writeFloat(rv.Float(), 64)
+	default:
+		enc.invalidType(rv.Type())
+	}
+}
+
+// validKind reports whether values of kind k can be written by writeReflect.
+func validKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Invalid, reflect.Struct, reflect.Chan, reflect.Func,
+		reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// startDetectingCyclesAfter is the number of nested maps, slices and pointers
+// after which writeInterface starts looking for cycles, like encoding/json does,
+// so that the cost is only paid by deeply nested values.
+const startDetectingCyclesAfter = 1000
+
+// ptrKey identifies a map, slice or pointer being written,
+// the length of slices is part of it as different slices can share the same array.
+type ptrKey struct {
+	ptr uintptr
+	len int
+}
+
+// enterPtr must be called before writing the content of the map, slice or pointer rv
+// and leavePtr once done. It returns false if rv is already being written,
+// in that case an UnsupportedValueError is set on the encoder and rv must not be written.
+func (enc *Encoder) enterPtr(rv reflect.Value) bool {
+	enc.ptrLevel++
+	if enc.ptrLevel <= startDetectingCyclesAfter {
+		return true
+	}
+	k := newPtrKey(rv)
+	if _, ok := enc.ptrSeen[k]; ok {
+		enc.ptrLevel--
+		enc.AddError(UnsupportedValueError(fmt.Sprintf("Unsupported value, encountered a cycle via %s", rv.Type().String())))
+		return false
+	}
+	if enc.ptrSeen == nil {
+		enc.ptrSeen = make(map[ptrKey]struct{})
+	}
+	enc.ptrSeen[k] = struct{}{}
+	return true
+}
+
+func (enc *Encoder) leavePtr(rv reflect.Value) {
+	if enc.ptrLevel > startDetectingCyclesAfter {
+		delete(enc.ptrSeen, newPtrKey(rv))
+	}
+	enc.ptrLevel--
+}
+
+func newPtrKey(rv reflect.Value) ptrKey {
+	k := ptrKey{ptr: rv.Pointer()}
+	if rv.Kind() == reflect.Slice {
+		k.len = rv.Len()
+	}
+	return k
+}
+
+func (enc *Encoder) invalidType(t reflect.Type) {
+	enc.AddError(InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, t.String())))
+}
+
+func (enc *Encoder) writeList(rv reflect.Value) {
+	enc.writeOpen('[')
+	for i := 0; i < rv.Len(); i++ {
+		enc.writeArraySep()
+		enc.writeInterface(rv.Index(i).Interface())
+	}
+	enc.writeClose(']')
+}
+
+type mapEntry struct {
+	key   string
+	value reflect.Value
+}
+
+func (enc *Encoder) writeMap(rv reflect.Value) {
+	keys := rv.MapKeys()
+	entries := make([]mapEntry, 0, len(keys))
+	for _, k := range keys {
+		key, ok := mapKey(k)
+		if !ok {
+			enc.invalidType(rv.Type())
+			return
+		}
+		entries = append(entries, mapEntry{key, rv.MapIndex(k)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	enc.writeOpen('{')
+	for _, e := range entries {
+		enc.writeKey(e.key)
+		enc.writeInterface(e.value.Interface())
+	}
+	enc.writeClose('}')
+}
+
+// mapKey returns the JSON key of the map key k, like encoding/json does.
+func mapKey(k reflect.Value) (string, bool) {
+	if k.Kind() == reflect.String {
+		return k.String(), true
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", true
+		}
+		b, err := tm.MarshalText()
+		return string(b), err == nil
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), true
+	}
+	return "", false
+}
+
+// isEmptyInterface reports whether v is a zero value for the OmitEmpty methods:
+// nil, false, 0, a nil pointer or an empty string, map, slice or array.
+func isEmptyInterface(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package gojay
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStringer struct{}
+
+func (testStringer) String() string {
+	return "stringer"
+}
+
+type testNamedInt int
+
+type testTextKey struct {
+	id int
+}
+
+func (k testTextKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + string(rune('0'+k.id))), nil
+}
+
+func TestEncoderInterfaceDynamic(t *testing.T) {
+	i := 42
+	s := "str"
+	var nilPtr *int
+	date := time.Date(2018, 5, 1, 10, 20, 30, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{name: "nil", v: nil, expected: `null`},
+		{name: "map-string-interface", v: map[string]interface{}{"b": 1, "a": "x", "c": nil}, expected: `{"a":"x","b":1,"c":null}`},
+		{name: "slice-interface", v: []interface{}{1, "a", true, nil, 1.5}, expected: `[1,"a",true,null,1.5]`},
+		{name: "slice-string", v: []string{"a", "b"}, expected: `["a","b"]`},
+		{name: "slice-string-nil", v: []string(nil), expected: `null`},
+		{name: "ptr-int", v: &i, expected: `42`},
+		{name: "ptr-string", v: &s, expected: `"str"`},
+		{name: "ptr-nil", v: nilPtr, expected: `null`},
+		{name: "error", v: errors.New("some error"), expected: `"some error"`},
+		{name: "stringer", v: testStringer{}, expected: `"stringer"`},
+		{name: "time", v: date, expected: `"2018-05-01T10:20:30Z"`},
+		{name: "embedded-json", v: &EmbeddedJSON{'{', '}'}, expected: `{}`},
+		{name: "bytes", v: []byte("hello"), expected: `"aGVsbG8="`},
+		{name: "named-int", v: testNamedInt(3), expected: `3`},
+		{name: "map-int-keys", v: map[int]string{10: "b", 2: "a"}, expected: `{"10":"b","2":"a"}`},
+		{name: "map-text-keys", v: map[testTextKey]int{{2}: 2, {1}: 1}, expected: `{"key-1":1,"key-2":2}`},
+		{name: "array", v: [2]int{1, 2}, expected: `[1,2]`},
+		{
+			name: "nested",
+			v: map[string]interface{}{
+				"list": []interface{}{map[string]interface{}{"k": []string{"v"}}, &i},
+				"obj":  &SubObject{test1: 1},
+				"strs": map[string][]int{"a": {1}},
+			},
+			expected: `{"list":[{"k":["v"]},42],"obj":{"test1":1,"test2":"","test3":0,"testBool":false,"sub":{}},"strs":{"a":[1]}}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b, err := Marshal(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, string(b), "Marshal result is not expected value")
+
+			builder := &strings.Builder{}
+			enc := NewEncoder(builder)
+			err = enc.Encode(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, builder.String(), "Encode result is not expected value")
+
+			builder.Reset()
+			enc = NewEncoder(builder)
+			enc.writeByte('{')
+			enc.AddInterfaceKey("k", testCase.v)
+			enc.writeByte('}')
+			enc.writeByte('[')
+			enc.AddInterface(testCase.v)
+			enc.writeByte(']')
+			_, err = enc.Write()
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(
+				t,
+				`{"k":`+testCase.expected+`}[`+testCase.expected+`]`,
+				builder.String(),
+				"AddInterface result is not expected value",
+			)
+		})
+	}
+}
+
+func TestEncoderInterfaceDynamicJSON(t *testing.T) {
+	v := map[string]interface{}{
+		"string":  "a\"b",
+		"numbers": []interface{}{1, int64(-2), uint8(3), 1.5, float32(0.25)},
+		"nested":  map[string]interface{}{"z": []string{}, "a": map[string]interface{}{}},
+		"bytes":   []byte{0, 1, 2},
+		"ints":    []int{1, 2, 3},
+		"null":    nil,
+		"bool":    false,
+	}
+	b, err := Marshal(v)
+	assert.Nil(t, err, "err should be nil")
+	expected, err := json.Marshal(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, string(expected), string(b), "Marshal should be consistent with encoding/json")
+}
+
+func TestEncoderInterfaceDynamicOmitEmpty(t *testing.T) {
+	var nilPtr *int
+	builder := &strings.Builder{}
+	enc := NewEncoder(builder)
+	enc.writeByte('{')
+	enc.AddInterfaceKeyOmitEmpty("nil", nil)
+	enc.AddInterfaceKeyOmitEmpty("nil-ptr", nilPtr)
+	enc.AddInterfaceKeyOmitEmpty("empty-map", map[string]interface{}{})
+	enc.AddInterfaceKeyOmitEmpty("empty-slice", []string{})
+	enc.AddInterfaceKeyOmitEmpty("empty-embedded", &EmbeddedJSON{})
+	enc.AddInterfaceKeyOmitEmpty("zero-named", testNamedInt(0))
+	enc.AddInterfaceKeyOmitEmpty("map", map[string]interface{}{"a": 1})
+	enc.AddInterfaceKeyOmitEmpty("error", errors.New("e"))
+	enc.writeByte('}')
+	_, err := enc.Write()
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, `{"map":{"a":1},"error":"e"}`, builder.String(), "builder.String() is not expected value")
+}
+
+func TestEncoderInterfaceDynamicErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    interface{}
+		err  string
+	}{
+		{name: "struct", v: struct{}{}, err: "Invalid type struct {} provided to Marshal"},
+		{name: "ptr-struct", v: &struct{}{}, err: "Invalid type *struct {} provided to Marshal"},
+		{name: "chan", v: make(chan int), err: "Invalid type chan int provided to Marshal"},
+		{name: "nested", v: map[string]interface{}{"a": []interface{}{struct{}{}}}, err: "Invalid type struct {} provided to Marshal"},
+		{name: "map-key", v: map[float64]int{1: 1}, err: "Invalid type map[float64]int provided to Marshal"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Marshal(testCase.v)
+			assert.NotNil(t, err, "err should not be nil")
+			assert.IsType(t, InvalidMarshalError(""), err, "err should be an InvalidMarshalError")
+			assert.Equal(t, testCase.err, err.Error(), "err.Error() is not expected value")
+
+			enc := NewEncoder(nil)
+			enc.writeByte('[')
+			enc.AddInterface(testCase.v)
+			assert.Equal(t, testCase.err, enc.Err().Error(), "enc.Err() is not expected value")
+		})
+	}
+}
+
+func TestEncoderInterfaceDynamicCycles(t *testing.T) {
+	m := map[string]interface{}{"a": 1}
+	m["self"] = m
+	s := []interface{}{1, nil}
+	s[1] = s
+	var p interface{}
+	p = &p
+	typed := map[string][]interface{}{}
+	typed["a"] = []interface{}{typed}
+	testCases := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "map", v: m},
+		{name: "slice", v: s},
+		{name: "pointer", v: p},
+		{name: "reflect-map", v: typed},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Marshal(testCase.v)
+			assert.IsType(t, UnsupportedValueError(""), err, "err should be an UnsupportedValueError")
+
+			builder := &strings.Builder{}
+			enc := BorrowEncoder(builder)
+			defer enc.Release()
+			err = enc.Encode(testCase.v)
+			assert.IsType(t, UnsupportedValueError(""), err, "err should be an UnsupportedValueError")
+			assert.Equal(t, "", builder.String(), "nothing should be written")
+			assert.Equal(t, 0, enc.ptrLevel, "enc.ptrLevel should be back to 0")
+		})
+	}
+	t.Run("deep-without-cycle", func(t *testing.T) {
+		var v interface{} = 1
+		for i := 0; i < startDetectingCyclesAfter+10; i++ {
+			v = []interface{}{v}
+		}
+		b, err := Marshal(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			strings.Repeat("[", startDetectingCyclesAfter+10)+"1"+strings.Repeat("]", startDetectingCyclesAfter+10),
+			string(b),
+			"b is not expected value",
+		)
+	})
+	t.Run("shared-not-cyclic", func(t *testing.T) {
+		shared := []interface{}{1}
+		var v interface{} = []interface{}{shared, shared}
+		for i := 0; i < startDetectingCyclesAfter; i++ {
+			v = []interface{}{v}
+		}
+		_, err := Marshal(v)
+		assert.Nil(t, err, "err should be nil")
+	})
+}
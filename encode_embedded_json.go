@@ -6,12 +6,8 @@ func (enc *Encoder) EncodeEmbeddedJSON(v *EmbeddedJSON) error {
 	if enc.isPooled == 1 {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
-	enc.buf = *v
-	_, err := enc.Write()
-	if err != nil {
-		return err
-	}
-	return nil
+	enc.writeBytes(*v)
+	return enc.flush()
 }
 
 func (enc *Encoder) encodeEmbeddedJSON(v *EmbeddedJSON) ([]byte, error) {
@@ -24,11 +20,11 @@ func (enc *Encoder) encodeEmbeddedJSON(v *EmbeddedJSON) ([]byte, error) {
 // It basically blindly writes the bytes to the final buffer. Therefore,
 // it expects the JSON to be of proper format.
 func (enc *Encoder) AddEmbeddedJSON(v *EmbeddedJSON) {
-	enc.grow(len(*v) + 4)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
+	enc.grow(len(*v) + 4)
+	enc.writeArraySep()
 	enc.writeBytes(*v)
 }
 
@@ -37,13 +33,13 @@ func (enc *Encoder) AddEmbeddedJSON(v *EmbeddedJSON) {
 // It basically blindly writes the bytes to the final buffer. Therefore,
 // it expects the JSON to be of proper format.
 func (enc *Encoder) AddEmbeddedJSONOmitEmpty(v *EmbeddedJSON) {
-	if v == nil || len(*v) == 0 {
+	if enc.err != nil {
 		return
 	}
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if v == nil || len(*v) == 0 {
+		return
 	}
+	enc.writeArraySep()
 	enc.writeBytes(*v)
 }
 
@@ -52,14 +48,11 @@ func (enc *Encoder) AddEmbeddedJSONOmitEmpty(v *EmbeddedJSON) {
 // It basically blindly writes the bytes to the final buffer. Therefore,
 // it expects the JSON to be of proper format.
 func (enc *Encoder) AddEmbeddedJSONKey(key string, v *EmbeddedJSON) {
-	enc.grow(len(key) + len(*v) + 5)
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
+	enc.grow(len(key) + len(*v) + 5)
+	enc.writeKey(key)
 	enc.writeBytes(*v)
 }
 
@@ -68,16 +61,13 @@ func (enc *Encoder) AddEmbeddedJSONKey(key string, v *EmbeddedJSON) {
 // It basically blindly writes the bytes to the final buffer. Therefore,
 // it expects the JSON to be of proper format.
 func (enc *Encoder) AddEmbeddedJSONKeyOmitEmpty(key string, v *EmbeddedJSON) {
+	if enc.err != nil {
+		return
+	}
 	if v == nil || len(*v) == 0 {
 		return
 	}
 	enc.grow(len(key) + len(*v) + 5)
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
+	enc.writeKey(key)
 	enc.writeBytes(*v)
 }
@@ -0,0 +1,40 @@
+package gojay
+
+// Err returns the first error which occurred while encoding, if any.
+//
+// Once an error is set, Add methods are no-ops and Encode methods return it.
+// BorrowEncoder returns an encoder without error.
+func (enc *Encoder) Err() error {
+	return enc.err
+}
+
+// AddError sets err as the encoder's error if none is set yet.
+// It lets a MarshalObject or MarshalArray implementation abort the encoding with its own error,
+// which is then returned by the Marshal and Encode functions.
+//
+//	func (u *User) MarshalObject(enc *gojay.Encoder) {
+//		if u.id == 0 {
+//			enc.AddError(errors.New("user has no id"))
+//			return
+//		}
+//		enc.AddIntKey("id", u.id)
+//	}
+func (enc *Encoder) AddError(err error) {
+	if enc.err == nil {
+		enc.err = err
+	}
+}
+
+// flush writes the buffer to the io.Writer and returns the first error of the encoder.
+// If an error occurred while encoding, the buffer is discarded as it doesn't hold valid JSON.
+func (enc *Encoder) flush() error {
+	if enc.err != nil {
+		enc.buf = enc.buf[:0]
+		return enc.err
+	}
+	_, err := enc.Write()
+	if err != nil {
+		enc.err = err
+	}
+	return err
+}
@@ -0,0 +1,160 @@
+package gojay
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestEncode = errors.New("test encode error")
+
+// testErrorObject aborts encoding with its own error when invalid is true
+type testErrorObject struct {
+	id      int
+	invalid bool
+}
+
+func (t *testErrorObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testErrorObject) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", t.id)
+	if t.invalid {
+		enc.AddError(errTestEncode)
+		return
+	}
+	enc.AddStringKey("status", "ok")
+}
+
+type testErrorObjects []*testErrorObject
+
+func (t testErrorObjects) MarshalArray(enc *Encoder) {
+	for _, e := range t {
+		enc.AddObject(e)
+	}
+}
+
+func (t testErrorObjects) IsNil() bool {
+	return t == nil
+}
+
+func TestEncoderErr(t *testing.T) {
+	t.Run("add-error", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		assert.Nil(t, enc.Err(), "enc.Err() should be nil")
+		enc.writeByte('{')
+		enc.AddIntKey("a", 1)
+		enc.AddError(errTestEncode)
+		enc.AddError(errors.New("second error"))
+		assert.Equal(t, errTestEncode, enc.Err(), "enc.Err() should be the first error")
+		// following calls are no-ops
+		enc.AddIntKey("b", 2)
+		enc.AddStringKey("c", "c")
+		enc.AddObjectKey("d", &testErrorObject{id: 1})
+		enc.AddNullKey("e")
+		enc.AddInterfaceKey("f", struct{}{})
+		assert.Equal(t, `{"a":1`, string(enc.buf), "nothing should be added after an error")
+		assert.Equal(t, errTestEncode, enc.Err(), "enc.Err() should be the first error")
+	})
+	t.Run("add-interface", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddInterface(struct{}{})
+		assert.IsType(t, InvalidMarshalError(""), enc.Err(), "enc.Err() should be an InvalidMarshalError")
+	})
+	t.Run("pool", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		enc.AddError(errTestEncode)
+		enc.Release()
+		enc = BorrowEncoder(nil)
+		defer enc.Release()
+		assert.Nil(t, enc.Err(), "enc.Err() should be nil when borrowing an encoder")
+	})
+}
+
+func TestEncoderErrorPropagation(t *testing.T) {
+	valid := testErrorObjects{{id: 1}, {id: 2}}
+	invalid := testErrorObjects{{id: 1}, {id: 2, invalid: true}, {id: 3}}
+	t.Run("marshal-object", func(t *testing.T) {
+		b, err := MarshalObject(valid[0])
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"id":1,"status":"ok"}`, string(b), "string(b) is not expected value")
+		_, err = MarshalObject(invalid[1])
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+	})
+	t.Run("marshal-array", func(t *testing.T) {
+		b, err := MarshalArray(valid)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[{"id":1,"status":"ok"},{"id":2,"status":"ok"}]`, string(b), "string(b) is not expected value")
+		_, err = MarshalArray(invalid)
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+		_, err = MarshalArray(TestEncodingFloatOmitEmpty{1, math.NaN()})
+		assert.IsType(t, UnsupportedValueError(""), err, "err should be an UnsupportedValueError")
+	})
+	t.Run("marshal", func(t *testing.T) {
+		_, err := Marshal(invalid)
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+		_, err = MarshalTo(nil, invalid[1])
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+	})
+	t.Run("encode-object", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.EncodeObject(invalid[1])
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+		assert.Equal(t, "", builder.String(), "an invalid document should not be written")
+		// the error is kept
+		err = enc.EncodeInt(1)
+		assert.Equal(t, errTestEncode, err, "err should be the first error")
+		assert.Equal(t, "", builder.String(), "nothing should be written after an error")
+	})
+	t.Run("encode-array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.EncodeArray(invalid)
+		assert.Equal(t, errTestEncode, err, "err should be the error added by MarshalObject")
+		assert.Equal(t, "", builder.String(), "an invalid document should not be written")
+	})
+	t.Run("encode", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.Encode(struct{}{})
+		assert.IsType(t, InvalidMarshalError(""), err, "err should be an InvalidMarshalError")
+		err = enc.Encode(valid)
+		assert.IsType(t, InvalidMarshalError(""), err, "err should be the first error")
+	})
+}
+
+type testStreamErrorChan chan *testErrorObject
+
+func (s testStreamErrorChan) MarshalStream(enc *StreamEncoder) {
+	select {
+	case <-enc.Done():
+		return
+	case o := <-s:
+		enc.AddObject(o)
+		enc.AddString("skipped")
+	}
+}
+
+func TestStreamEncoderError(t *testing.T) {
+	builder := &strings.Builder{}
+	s := make(testStreamErrorChan)
+	enc := Stream.NewEncoder(builder).LineDelimited()
+	go enc.EncodeStream(s)
+	s <- &testErrorObject{id: 1}
+	s <- &testErrorObject{id: 2, invalid: true}
+	<-enc.Done()
+	assert.Equal(t, errTestEncode, enc.Err(), "enc.Err() should be the error added by MarshalObject")
+	assert.Equal(t, "{\"id\":1,\"status\":\"ok\"}\n\"skipped\"\n", builder.String(), "builder.String() is not expected value")
+}
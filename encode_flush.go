@@ -0,0 +1,31 @@
+package gojay
+
+// SetFlushThreshold makes the encoder write its buffer to its io.Writer whenever it holds at least n bytes,
+// so that encoding a large document with EncodeObject or EncodeArray doesn't require holding it in memory.
+// The buffer is written between two values of an object or an array, a single value is never split.
+//
+// If writing fails, the error is returned by the Encode method and the rest of the document is discarded.
+// Flushing requires an io.Writer, it has no effect with Marshal functions.
+// A threshold of 0 or less disables flushing, it is the default.
+func (enc *Encoder) SetFlushThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	enc.flushThreshold = n
+}
+
+// flushIfFull writes the buffer to the io.Writer if it exceeds the flush threshold.
+func (enc *Encoder) flushIfFull() {
+	if enc.flushThreshold == 0 || len(enc.buf) < enc.flushThreshold || enc.w == nil {
+		return
+	}
+	if enc.err != nil {
+		// the document won't be valid, keep memory bounded
+		enc.buf = enc.buf[:0]
+		return
+	}
+	_, err := enc.Write()
+	if err != nil {
+		enc.err = err
+	}
+}
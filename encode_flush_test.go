@@ -0,0 +1,156 @@
+package gojay
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testChunkWriter records the size of each write
+type testChunkWriter struct {
+	bytes.Buffer
+	chunks []int
+	// fail makes writes fail after n writes if > 0
+	fail int
+	// short makes writes accept half of the bytes
+	short bool
+}
+
+func (w *testChunkWriter) Write(b []byte) (int, error) {
+	if w.fail > 0 && len(w.chunks) >= w.fail {
+		return 0, errors.New("Test Error")
+	}
+	w.chunks = append(w.chunks, len(b))
+	if w.short && len(b) > 1 {
+		return w.Buffer.Write(b[:len(b)/2])
+	}
+	return w.Buffer.Write(b)
+}
+
+type testFlushArr []*testIndentObject
+
+func (t testFlushArr) MarshalArray(enc *Encoder) {
+	for _, e := range t {
+		enc.AddObject(e)
+	}
+}
+
+func (t testFlushArr) IsNil() bool {
+	return t == nil
+}
+
+func testFlushData(n int) testFlushArr {
+	v := make(testFlushArr, n)
+	for i := range v {
+		v[i] = &testIndentObject{
+			id:   i,
+			name: strings.Repeat("x", 50),
+			tags: TestEncodingArrStrings{"a", "b", "c"},
+		}
+	}
+	return v
+}
+
+func TestEncoderFlushThreshold(t *testing.T) {
+	v := testFlushData(1000)
+	expected, err := MarshalArray(v)
+	assert.Nil(t, err, "err should be nil")
+	expectedStr := string(expected)
+	t.Run("array", func(t *testing.T) {
+		w := &testChunkWriter{}
+		enc := BorrowEncoder(w)
+		defer enc.Release()
+		enc.SetFlushThreshold(1024)
+		err := enc.EncodeArray(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, expectedStr, w.String(), "flushed result should be equal to the result of MarshalArray")
+		assert.True(t, len(w.chunks) > 50, "buffer should have been written several times")
+		for _, n := range w.chunks {
+			assert.True(t, n < 1024+200, "chunks should be bounded by the threshold")
+		}
+	})
+	t.Run("object", func(t *testing.T) {
+		w := &testChunkWriter{}
+		enc := BorrowEncoder(w)
+		defer enc.Release()
+		enc.SetFlushThreshold(16)
+		err := enc.EncodeObject(v[0])
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"id":0,"name":"`+strings.Repeat("x", 50)+`","tags":["a","b","c"],"empty":[]}`,
+			w.String(),
+			"flushed result is not expected value",
+		)
+		assert.True(t, len(w.chunks) > 1, "buffer should have been written several times")
+	})
+	t.Run("indent", func(t *testing.T) {
+		w := &testChunkWriter{}
+		enc := BorrowEncoder(w)
+		defer enc.Release()
+		enc.SetIndent("", "  ")
+		enc.SetFlushThreshold(1)
+		err := enc.EncodeArray(v[:3])
+		assert.Nil(t, err, "err should be nil")
+		b, err := MarshalArrayIndent(v[:3], "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, string(b), w.String(), "flushed result should be equal to the result of MarshalArrayIndent")
+	})
+	t.Run("disabled", func(t *testing.T) {
+		w := &testChunkWriter{}
+		enc := BorrowEncoder(w)
+		defer enc.Release()
+		enc.SetFlushThreshold(1)
+		enc.SetFlushThreshold(-1)
+		err := enc.EncodeArray(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, []int{len(expected)}, w.chunks, "buffer should have been written once")
+	})
+	t.Run("write-error", func(t *testing.T) {
+		w := &testChunkWriter{fail: 2}
+		enc := BorrowEncoder(w)
+		defer enc.Release()
+		enc.SetFlushThreshold(1024)
+		err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddArrayKey("data", v)
+		}))
+		assert.NotNil(t, err, "err should not be nil")
+		assert.Equal(t, "Test Error", err.Error(), "err should be the error of the writer")
+		assert.True(t, len(enc.buf) < 1024+200, "buffer should be bounded after an error")
+	})
+}
+
+func TestEncoderWrite(t *testing.T) {
+	t.Run("no-writer", func(t *testing.T) {
+		enc := NewEncoder(nil)
+		err := enc.EncodeInt(1)
+		assert.NotNil(t, err, "err should not be nil")
+		assert.IsType(t, NoWriterError(""), err, "err should be a NoWriterError")
+	})
+	t.Run("short-write", func(t *testing.T) {
+		w := &testChunkWriter{short: true}
+		enc := NewEncoder(w)
+		enc.writeString(`"test"`)
+		n, err := enc.Write()
+		assert.Equal(t, io.ErrShortWrite, err, "err should be io.ErrShortWrite")
+		assert.Equal(t, 3, n, "n should be the number of bytes written")
+		assert.Equal(t, `st"`, string(enc.buf), "bytes not written should be kept")
+		w.short = false
+		n, err = enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, 3, n, "n should be the number of bytes written")
+		assert.Equal(t, `"test"`, w.String(), "w.String() is not expected value")
+		assert.Len(t, enc.buf, 0, "buffer should be empty")
+	})
+	t.Run("writer-error", func(t *testing.T) {
+		enc := NewEncoder(TestWriterError(""))
+		enc.writeString(`"test"`)
+		_, err := enc.Write()
+		assert.NotNil(t, err, "err should not be nil")
+		assert.Equal(t, `"test"`, string(enc.buf), "bytes not written should be kept")
+	})
+}
@@ -0,0 +1,110 @@
+package gojay
+
+import "bytes"
+
+// SetIndent instructs the encoder to format each subsequent encoded value as if indented by MarshalIndent.
+// Each element of an object or array begins on a new line starting with prefix
+// followed by one or more copies of indent according to the nesting depth.
+// Calling SetIndent("", "") disables indentation.
+//
+// Indentation is written by the Add methods as they are called,
+// so existing MarshalerObject and MarshalerArray implementations are indented without changes.
+// The output of json.Marshaler values is indented as well, embedded JSON and values
+// encoded as strings (AddObjectAsString, ...) are written as is.
+func (enc *Encoder) SetIndent(prefix, indent string) {
+	enc.prefix = prefix
+	enc.indent = indent
+	enc.pretty = prefix != "" || indent != ""
+}
+
+// MarshalIndent is like Marshal but applies SetIndent to format the output.
+func MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	b := &bytes.Buffer{}
+	enc := BorrowEncoder(b)
+	defer enc.Release()
+	enc.SetIndent(prefix, indent)
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// MarshalObjectIndent is like MarshalObject but applies SetIndent to format the output.
+func MarshalObjectIndent(v MarshalerObject, prefix, indent string) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	enc.grow(512)
+	defer enc.Release()
+	enc.SetIndent(prefix, indent)
+	b, err := enc.encodeObject(v)
+	return copyBytes(b), err
+}
+
+// MarshalArrayIndent is like MarshalArray but applies SetIndent to format the output.
+func MarshalArrayIndent(v MarshalerArray, prefix, indent string) ([]byte, error) {
+	enc := BorrowEncoder(nil)
+	enc.grow(512)
+	defer enc.Release()
+	enc.SetIndent(prefix, indent)
+	b, err := enc.encodeArray(v)
+	return copyBytes(b), err
+}
+
+// writeArraySep writes the separator preceding an element of an array.
+func (enc *Encoder) writeArraySep() {
+	enc.flushIfFull()
+	r := enc.getPreviousRune()
+	if r != '[' {
+		enc.writeByte(',')
+	}
+	if enc.pretty {
+		enc.writeIndent(enc.depth)
+	}
+}
+
+// writeKey writes the separator preceding a key of an object, followed by the key.
+func (enc *Encoder) writeKey(key string) {
+	enc.flushIfFull()
+	r := enc.getPreviousRune()
+	if r != '{' {
+		enc.writeByte(',')
+	}
+	if enc.pretty {
+		enc.writeIndent(enc.depth)
+		enc.writeByte('"')
+		enc.writeStringEscape(key)
+		enc.writeTwoBytes('"', ':')
+		enc.writeByte(' ')
+		return
+	}
+	enc.writeByte('"')
+	enc.writeStringEscape(key)
+	enc.writeBytes(objKey)
+}
+
+// writeOpen writes the opening brace or bracket c of an object or array.
+func (enc *Encoder) writeOpen(c byte) {
+	enc.depth++
+	enc.writeByte(c)
+}
+
+// writeClose writes the closing brace or bracket c of an object or array,
+// on its own line if indentation is enabled and the object or array isn't empty.
+func (enc *Encoder) writeClose(c byte) {
+	enc.depth--
+	if enc.pretty {
+		r := enc.getPreviousRune()
+		if r != '{' && r != '[' {
+			enc.writeIndent(enc.depth)
+		}
+	}
+	enc.writeByte(c)
+}
+
+func (enc *Encoder) writeIndent(depth int) {
+	enc.writeByte('\n')
+	enc.writeString(enc.prefix)
+	for i := 0; i < depth; i++ {
+		enc.writeString(enc.indent)
+	}
+}
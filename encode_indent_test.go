@@ -0,0 +1,132 @@
+package gojay
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testIndentObject struct {
+	id    int
+	name  string
+	tags  TestEncodingArrStrings
+	empty TestEncodingArrStrings
+	sub   *testIndentObject
+	body  *SubObject
+}
+
+func (t *testIndentObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testIndentObject) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", t.id)
+	enc.AddStringKey("name", t.name)
+	enc.AddArrayKey("tags", t.tags)
+	enc.AddArrayKey("empty", t.empty)
+	enc.AddObjectKeyOmitEmpty("sub", t.sub)
+	if t.body != nil {
+		enc.AddObjectAsStringKey("body", t.body)
+	}
+}
+
+func TestEncoderIndent(t *testing.T) {
+	v := &testIndentObject{
+		id:   1,
+		name: "root",
+		tags: TestEncodingArrStrings{"a", "b"},
+		sub: &testIndentObject{
+			id:   2,
+			name: "child",
+		},
+	}
+	expected := `{
+  "id": 1,
+  "name": "root",
+  "tags": [
+    "a",
+    "b"
+  ],
+  "empty": [],
+  "sub": {
+    "id": 2,
+    "name": "child",
+    "tags": [],
+    "empty": []
+  }
+}`
+	t.Run("marshal-object", func(t *testing.T) {
+		b, err := MarshalObjectIndent(v, "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, expected, string(b), "MarshalObjectIndent result is not expected value")
+		// check against encoding/json
+		out := &bytes.Buffer{}
+		err = json.Indent(out, []byte(`{"id":1,"name":"root","tags":["a","b"],"empty":[],"sub":{"id":2,"name":"child","tags":[],"empty":[]}}`), "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, out.String(), string(b), "MarshalObjectIndent should be consistent with json.Indent")
+	})
+	t.Run("marshal", func(t *testing.T) {
+		b, err := MarshalIndent(v, "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, expected, string(b), "MarshalIndent result is not expected value")
+	})
+	t.Run("marshal-non-container", func(t *testing.T) {
+		b, err := MarshalIndent(42, "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "42", string(b), "MarshalIndent result is not expected value")
+	})
+	t.Run("marshal-array", func(t *testing.T) {
+		b, err := MarshalArrayIndent(TestEncodingArrStrings{"a", "b"}, ">", "\t")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "[\n>\t\"a\",\n>\t\"b\"\n>]", string(b), "MarshalArrayIndent result is not expected value")
+	})
+	t.Run("prefix-only", func(t *testing.T) {
+		b, err := MarshalArrayIndent(TestEncodingArrStrings{"a"}, "//", "")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "[\n//\"a\"\n//]", string(b), "MarshalArrayIndent result is not expected value")
+	})
+	t.Run("encoder", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetIndent("", "  ")
+		err := enc.EncodeObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, expected, builder.String(), "builder.String() is not expected value")
+
+		builder.Reset()
+		enc.SetIndent("", "")
+		err = enc.EncodeObject(v.sub)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"id":2,"name":"child","tags":[],"empty":[]}`,
+			builder.String(),
+			"SetIndent with empty strings should disable indentation",
+		)
+	})
+	t.Run("as-string-stays-compact", func(t *testing.T) {
+		b, err := MarshalObjectIndent(&testIndentObject{id: 1, body: &SubObject{test1: 1}}, "", " ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			"{\n \"id\": 1,\n \"name\": \"\",\n \"tags\": [],\n \"empty\": [],\n"+
+				` "body": "{\"test1\":1,\"test2\":\"\",\"test3\":0,\"testBool\":false,\"sub\":{}}"`+"\n}",
+			string(b),
+			"MarshalObjectIndent result is not expected value",
+		)
+	})
+}
+
+func TestEncoderIndentPool(t *testing.T) {
+	enc := BorrowEncoder(nil)
+	enc.SetIndent("", "  ")
+	enc.Release()
+	enc = BorrowEncoder(nil)
+	defer enc.Release()
+	assert.False(t, enc.pretty, "pretty should be reset when borrowing an encoder")
+	assert.Equal(t, 0, enc.depth, "depth should be reset when borrowing an encoder")
+}
@@ -1,8 +1,9 @@
 package gojay
 
 import (
-	"fmt"
-	"reflect"
+	"database/sql"
+	"encoding"
+	"encoding/json"
 )
 
 // Encode encodes a value to JSON.
@@ -27,25 +28,36 @@ func (enc *Encoder) Encode(v interface{}) error {
 	case int64:
 		return enc.EncodeInt64(vt)
 	case int32:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeInt32(vt)
+	case int16:
+		return enc.EncodeInt16(vt)
 	case int8:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeInt8(vt)
 	case uint64:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeUint64(vt)
 	case uint32:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeUint32(vt)
 	case uint16:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeUint16(vt)
 	case uint8:
-		return enc.EncodeInt(int(vt))
+		return enc.EncodeUint8(vt)
 	case float64:
 		return enc.EncodeFloat(vt)
 	case float32:
 		return enc.EncodeFloat32(vt)
 	case *EmbeddedJSON:
 		return enc.EncodeEmbeddedJSON(vt)
+	case sql.NullString, sql.NullInt64, sql.NullFloat64, sql.NullBool, sql.NullTime,
+		*sql.NullString, *sql.NullInt64, *sql.NullFloat64, *sql.NullBool, *sql.NullTime:
+		_, _ = enc.encodeSQLNull(vt)
+		return enc.flush()
+	case json.Marshaler:
+		return enc.EncodeJSONMarshaler(vt)
+	case encoding.TextMarshaler:
+		return enc.EncodeTextMarshaler(vt)
 	default:
-		return InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, reflect.TypeOf(vt).String()))
+		enc.writeInterface(vt)
+		return enc.flush()
 	}
 }
 
@@ -63,30 +75,63 @@ func (enc *Encoder) AddInterface(value interface{}) {
 	case int:
 		enc.AddInt(vt)
 	case int64:
-		enc.AddInt(int(vt))
+		enc.AddInt64(vt)
 	case int32:
-		enc.AddInt(int(vt))
+		enc.AddInt32(vt)
+	case int16:
+		enc.AddInt16(vt)
 	case int8:
-		enc.AddInt(int(vt))
+		enc.AddInt8(vt)
 	case uint64:
-		enc.AddInt(int(vt))
+		enc.AddUint64(vt)
 	case uint32:
-		enc.AddInt(int(vt))
+		enc.AddUint32(vt)
 	case uint16:
-		enc.AddInt(int(vt))
+		enc.AddUint16(vt)
 	case uint8:
-		enc.AddInt(int(vt))
+		enc.AddUint8(vt)
 	case float64:
 		enc.AddFloat(vt)
 	case float32:
 		enc.AddFloat32(vt)
+	case *EmbeddedJSON:
+		if vt == nil {
+			enc.AddNull()
+		} else {
+			enc.AddEmbeddedJSON(vt)
+		}
+	case sql.NullString:
+		enc.AddSQLNullString(&vt)
+	case *sql.NullString:
+		enc.AddSQLNullString(vt)
+	case sql.NullInt64:
+		enc.AddSQLNullInt64(&vt)
+	case *sql.NullInt64:
+		enc.AddSQLNullInt64(vt)
+	case sql.NullFloat64:
+		enc.AddSQLNullFloat64(&vt)
+	case *sql.NullFloat64:
+		enc.AddSQLNullFloat64(vt)
+	case sql.NullBool:
+		enc.AddSQLNullBool(&vt)
+	case *sql.NullBool:
+		enc.AddSQLNullBool(vt)
+	case sql.NullTime:
+		enc.AddSQLNullTime(&vt)
+	case *sql.NullTime:
+		enc.AddSQLNullTime(vt)
+	case json.Marshaler:
+		enc.AddJSONMarshaler(vt)
+	case encoding.TextMarshaler:
+		enc.AddTextMarshaler(vt)
+	case nil:
+		enc.AddNull()
 	default:
-		t := reflect.TypeOf(vt)
-		if t != nil {
-			enc.err = InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, t.String()))
+		if enc.err != nil {
 			return
 		}
-		return
+		enc.writeArraySep()
+		enc.writeInterface(vt)
 	}
 }
 
@@ -104,32 +149,63 @@ func (enc *Encoder) AddInterfaceKey(key string, value interface{}) {
 	case int:
 		enc.AddIntKey(key, vt)
 	case int64:
-		enc.AddIntKey(key, int(vt))
+		enc.AddInt64Key(key, vt)
 	case int32:
-		enc.AddIntKey(key, int(vt))
+		enc.AddInt32Key(key, vt)
 	case int16:
-		enc.AddIntKey(key, int(vt))
+		enc.AddInt16Key(key, vt)
 	case int8:
-		enc.AddIntKey(key, int(vt))
+		enc.AddInt8Key(key, vt)
 	case uint64:
-		enc.AddIntKey(key, int(vt))
+		enc.AddUint64Key(key, vt)
 	case uint32:
-		enc.AddIntKey(key, int(vt))
+		enc.AddUint32Key(key, vt)
 	case uint16:
-		enc.AddIntKey(key, int(vt))
+		enc.AddUint16Key(key, vt)
 	case uint8:
-		enc.AddIntKey(key, int(vt))
+		enc.AddUint8Key(key, vt)
 	case float64:
 		enc.AddFloatKey(key, vt)
 	case float32:
 		enc.AddFloat32Key(key, vt)
+	case *EmbeddedJSON:
+		if vt == nil {
+			enc.AddNullKey(key)
+		} else {
+			enc.AddEmbeddedJSONKey(key, vt)
+		}
+	case sql.NullString:
+		enc.AddSQLNullStringKey(key, &vt)
+	case *sql.NullString:
+		enc.AddSQLNullStringKey(key, vt)
+	case sql.NullInt64:
+		enc.AddSQLNullInt64Key(key, &vt)
+	case *sql.NullInt64:
+		enc.AddSQLNullInt64Key(key, vt)
+	case sql.NullFloat64:
+		enc.AddSQLNullFloat64Key(key, &vt)
+	case *sql.NullFloat64:
+		enc.AddSQLNullFloat64Key(key, vt)
+	case sql.NullBool:
+		enc.AddSQLNullBoolKey(key, &vt)
+	case *sql.NullBool:
+		enc.AddSQLNullBoolKey(key, vt)
+	case sql.NullTime:
+		enc.AddSQLNullTimeKey(key, &vt)
+	case *sql.NullTime:
+		enc.AddSQLNullTimeKey(key, vt)
+	case json.Marshaler:
+		enc.AddJSONMarshalerKey(key, vt)
+	case encoding.TextMarshaler:
+		enc.AddTextMarshalerKey(key, vt)
+	case nil:
+		enc.AddNullKey(key)
 	default:
-		t := reflect.TypeOf(vt)
-		if t != nil {
-			enc.err = InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, t.String()))
+		if enc.err != nil {
 			return
 		}
-		return
+		enc.writeKey(key)
+		enc.writeInterface(vt)
 	}
 }
 
@@ -147,31 +223,58 @@ func (enc *Encoder) AddInterfaceKeyOmitEmpty(key string, v interface{}) {
 	case int:
 		enc.AddIntKeyOmitEmpty(key, vt)
 	case int64:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddInt64KeyOmitEmpty(key, vt)
 	case int32:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddInt32KeyOmitEmpty(key, vt)
 	case int16:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddInt16KeyOmitEmpty(key, vt)
 	case int8:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddInt8KeyOmitEmpty(key, vt)
 	case uint64:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddUint64KeyOmitEmpty(key, vt)
 	case uint32:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddUint32KeyOmitEmpty(key, vt)
 	case uint16:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddUint16KeyOmitEmpty(key, vt)
 	case uint8:
-		enc.AddIntKeyOmitEmpty(key, int(vt))
+		enc.AddUint8KeyOmitEmpty(key, vt)
 	case float64:
 		enc.AddFloatKeyOmitEmpty(key, vt)
 	case float32:
 		enc.AddFloat32KeyOmitEmpty(key, vt)
+	case *EmbeddedJSON:
+		enc.AddEmbeddedJSONKeyOmitEmpty(key, vt)
+	case sql.NullString:
+		enc.AddSQLNullStringKeyOmitEmpty(key, &vt)
+	case *sql.NullString:
+		enc.AddSQLNullStringKeyOmitEmpty(key, vt)
+	case sql.NullInt64:
+		enc.AddSQLNullInt64KeyOmitEmpty(key, &vt)
+	case *sql.NullInt64:
+		enc.AddSQLNullInt64KeyOmitEmpty(key, vt)
+	case sql.NullFloat64:
+		enc.AddSQLNullFloat64KeyOmitEmpty(key, &vt)
+	case *sql.NullFloat64:
+		enc.AddSQLNullFloat64KeyOmitEmpty(key, vt)
+	case sql.NullBool:
+		enc.AddSQLNullBoolKeyOmitEmpty(key, &vt)
+	case *sql.NullBool:
+		enc.AddSQLNullBoolKeyOmitEmpty(key, vt)
+	case sql.NullTime:
+		enc.AddSQLNullTimeKeyOmitEmpty(key, &vt)
+	case *sql.NullTime:
+		enc.AddSQLNullTimeKeyOmitEmpty(key, vt)
+	case json.Marshaler:
+		enc.AddJSONMarshalerKeyOmitEmpty(key, vt)
+	case encoding.TextMarshaler:
+		enc.AddTextMarshalerKeyOmitEmpty(key, vt)
+	case nil:
+		return
 	default:
-		t := reflect.TypeOf(vt)
-		if t != nil {
-			enc.err = InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, t.String()))
+		if enc.err != nil || isEmptyInterface(vt) {
 			return
 		}
-		return
+		enc.writeKey(key)
+		enc.writeInterface(vt)
 	}
 }
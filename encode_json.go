@@ -0,0 +1,126 @@
+package gojay
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// EncodeJSONMarshaler encodes v to JSON using its MarshalJSON method.
+func (enc *Encoder) EncodeJSONMarshaler(v json.Marshaler) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeJSONMarshaler(v)
+	return enc.flush()
+}
+
+func (enc *Encoder) encodeJSONMarshaler(v json.Marshaler) ([]byte, error) {
+	enc.writeJSON(v)
+	return enc.buf, enc.err
+}
+
+// AddJSONMarshaler adds a value implementing json.Marshaler to be encoded,
+// must be used inside a slice or array encoding (does not encode a key).
+//
+// Like with encoding/json, the output of MarshalJSON is validated and compacted,
+// or indented if SetIndent is used. Invalid output is reported as a MarshalerError.
+func (enc *Encoder) AddJSONMarshaler(v json.Marshaler) {
+	if enc.err != nil {
+		return
+	}
+	enc.writeArraySep()
+	enc.writeJSON(v)
+}
+
+// AddJSONMarshalerKey adds a value implementing json.Marshaler to be encoded,
+// must be used inside an object as it will encode a key.
+//
+// Like with encoding/json, the output of MarshalJSON is validated and compacted,
+// or indented if SetIndent is used. Invalid output is reported as a MarshalerError.
+func (enc *Encoder) AddJSONMarshalerKey(key string, v json.Marshaler) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(key) + 5)
+	enc.writeKey(key)
+	enc.writeJSON(v)
+}
+
+// AddJSONMarshalerKeyOmitEmpty adds a value implementing json.Marshaler to be encoded
+// or skips it if it is nil.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddJSONMarshalerKeyOmitEmpty(key string, v json.Marshaler) {
+	if isNilPointer(v) {
+		return
+	}
+	enc.AddJSONMarshalerKey(key, v)
+}
+
+// writeJSON writes the result of v's MarshalJSON method, or null if v is nil.
+// If MarshalJSON returns an error or invalid JSON, a MarshalerError is kept as the encoder's error
+// and null is written instead.
+func (enc *Encoder) writeJSON(v json.Marshaler) {
+	if isNilPointer(v) {
+		enc.writeString("null")
+		return
+	}
+	b, err := v.MarshalJSON()
+	if err == nil {
+		err = enc.appendJSON(b)
+	}
+	if err != nil {
+		if enc.err == nil {
+			enc.err = MarshalerError{Type: reflect.TypeOf(v), Err: err}
+		}
+		// keep output valid
+		enc.writeString("null")
+	}
+}
+
+// appendJSON validates b and appends it to the buffer, compacted or indented
+// according to the encoder's settings and with HTML characters escaped if enabled.
+// The buffer is left untouched if b is not valid JSON.
+func (enc *Encoder) appendJSON(b []byte) error {
+	// encoding/json keeps trailing white spaces, they have no meaning in an embedded value
+	b = bytes.TrimRight(b, " \t\r\n")
+	n := len(enc.buf)
+	dst := bytes.NewBuffer(enc.buf)
+	var err error
+	if enc.pretty {
+		// the first line follows the key or separator, next ones are indented from the current depth
+		err = json.Indent(dst, b, enc.prefix+strings.Repeat(enc.indent, enc.depth), enc.indent)
+	} else {
+		err = json.Compact(dst, b)
+	}
+	if err != nil {
+		return err
+	}
+	out := dst.Bytes()
+	if enc.escapeHTML && bytes.ContainsAny(out[n:], "<>&\u2028\u2029") {
+		esc := &bytes.Buffer{}
+		json.HTMLEscape(esc, out[n:])
+		out = append(out[:n], esc.Bytes()...)
+	}
+	enc.buf = out
+	return nil
+}
+
+// JSONMarshaler returns a json.Marshaler encoding v with gojay.
+// It allows using gojay for types passed to libraries relying on encoding/json.
+//
+//	json.NewEncoder(w).Encode(gojay.JSONMarshaler(user))
+func JSONMarshaler(v MarshalerObject) json.Marshaler {
+	return jsonMarshaler{v}
+}
+
+type jsonMarshaler struct {
+	v MarshalerObject
+}
+
+func (m jsonMarshaler) MarshalJSON() ([]byte, error) {
+	// not pooled as the buffer is handed to the caller
+	enc := NewEncoder(nil)
+	return enc.encodeObject(m.v)
+}
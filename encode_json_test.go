@@ -0,0 +1,155 @@
+package gojay
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJSONMarshalValue implements both json.Marshaler and encoding.TextMarshaler
+type testJSONMarshalValue struct {
+	raw string
+}
+
+func (v *testJSONMarshalValue) MarshalJSON() ([]byte, error) {
+	if v.raw == "" {
+		return nil, errors.New("empty value")
+	}
+	return []byte(v.raw), nil
+}
+
+func (v *testJSONMarshalValue) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
+func TestEncoderJSONMarshaler(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		var nilValue *testJSONMarshalValue
+		enc.writeByte('{')
+		enc.AddJSONMarshalerKey("a", &testJSONMarshalValue{raw: `{"b":1}`})
+		enc.AddJSONMarshalerKey("nil", nilValue)
+		enc.AddJSONMarshalerKeyOmitEmpty("omit", nilValue)
+		enc.AddInterfaceKey("raw", json.RawMessage(`[1,2]`))
+		enc.AddInterfaceKeyOmitEmpty("c", &testJSONMarshalValue{raw: `true`})
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"a":{"b":1},"nil":null,"raw":[1,2],"c":true}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddJSONMarshaler(&testJSONMarshalValue{raw: `1`})
+		enc.AddInterface(&testJSONMarshalValue{raw: `"x"`})
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[1,"x"]`, builder.String(), "json.Marshaler should take precedence")
+	})
+	t.Run("error", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddJSONMarshaler(&testJSONMarshalValue{})
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[null]`, builder.String(), "builder.String() is not expected value")
+		assert.IsType(t, MarshalerError{}, enc.err, "enc.err should be of type MarshalerError")
+	})
+	t.Run("compact", func(t *testing.T) {
+		b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddJSONMarshalerKey("a", &testJSONMarshalValue{raw: "{ \"b\" : [1,\n 2] }\n"})
+			enc.AddIntKey("c", 3)
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":{"b":[1,2]},"c":3}`, string(b), "b is not expected value")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			raw  string
+		}{
+			{name: "truncated", raw: `{"b":`},
+			{name: "trailing-data", raw: `1 2`},
+			{name: "bare-word", raw: `nope`},
+			{name: "blank", raw: ` `},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				builder := &strings.Builder{}
+				enc := BorrowEncoder(builder)
+				defer enc.Release()
+				enc.writeByte('[')
+				enc.AddJSONMarshaler(&testJSONMarshalValue{raw: testCase.raw})
+				enc.writeByte(']')
+				_, err := enc.Write()
+				assert.Nil(t, err, "err should be nil")
+				assert.Equal(t, `[null]`, builder.String(), "builder.String() is not expected value")
+				assert.IsType(t, MarshalerError{}, enc.err, "enc.err should be of type MarshalerError")
+
+				_, err = Marshal(&testJSONMarshalValue{raw: testCase.raw})
+				assert.IsType(t, MarshalerError{}, err, "err should be of type MarshalerError")
+			})
+		}
+	})
+	t.Run("escape-html", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetEscapeHTML(true)
+		err := enc.Encode(&testJSONMarshalValue{raw: `{"a":"\u003cb\u003e\u0026"}`})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":"\u003cb\u003e\u0026"}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("indent", func(t *testing.T) {
+		b, err := MarshalObjectIndent(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddJSONMarshalerKey("a", &testJSONMarshalValue{raw: `{"b":[1,2]}`})
+			enc.AddJSONMarshalerKey("c", &testJSONMarshalValue{raw: `[]`})
+		}), ">", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			"{\n>  \"a\": {\n>    \"b\": [\n>      1,\n>      2\n>    ]\n>  },\n>  \"c\": []\n>}",
+			string(b),
+			"b is not expected value",
+		)
+	})
+	t.Run("marshal-api", func(t *testing.T) {
+		b, err := Marshal(&testJSONMarshalValue{raw: `{"a":1}`})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":1}`, string(b), "b is not expected value")
+		_, err = Marshal(&testJSONMarshalValue{})
+		assert.NotNil(t, err, "err should not be nil")
+	})
+	t.Run("encode-api", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.Encode(json.RawMessage(`{"a":1}`))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":1}`, builder.String(), "builder.String() is not expected value")
+	})
+}
+
+func TestJSONMarshaler(t *testing.T) {
+	v := &testObject{}
+	b, err := json.Marshal(map[string]interface{}{"v": JSONMarshaler(v)})
+	assert.Nil(t, err, "err should be nil")
+	expected, _ := MarshalObject(v)
+	assert.Equal(t, `{"v":`+string(expected)+`}`, string(b), "b is not expected value")
+}
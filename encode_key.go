@@ -0,0 +1,331 @@
+package gojay
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// Key is a precompiled object key, it holds the key already escaped and quoted
+// so that the K methods (AddStringK, AddIntK, ...) write it in a single copy.
+//
+// Keys are immutable and safe for concurrent use, they are meant to be created once
+// with NewKey and reused across encodings:
+//
+//	var keyName = gojay.NewKey("name")
+//
+//	func (u *User) MarshalObject(enc *gojay.Encoder) {
+//		enc.AddStringK(keyName, u.Name)
+//	}
+type Key struct {
+	name string
+	// b is the separator and the key as written between two fields: ,"name":
+	b []byte
+	// plain is true if the key is escaped the same way whatever the encoder's options
+	plain bool
+	// first is true for the first key of an ObjectLayout, b then holds no separator
+	first bool
+}
+
+// NewKey returns the precompiled Key for name.
+func NewKey(name string) Key {
+	enc := &Encoder{buf: make([]byte, 0, len(name)+4)}
+	enc.writeTwoBytes(',', '"')
+	enc.writeStringEscape(name)
+	enc.writeBytes(objKey)
+	plain := true
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c >= utf8.RuneSelf || c == '<' || c == '>' || c == '&' {
+			plain = false
+			break
+		}
+	}
+	return Key{name: name, b: enc.buf, plain: plain}
+}
+
+// String returns the unescaped name of the key.
+func (k Key) String() string {
+	return k.name
+}
+
+// writeKeyK is like writeKey for a precompiled key.
+func (enc *Encoder) writeKeyK(k Key) {
+	// keys escaped differently by SetEscapeHTML or SetASCIIOnly are escaped again
+	if k.b == nil || (!k.plain && (enc.escapeHTML || enc.asciiOnly)) {
+		enc.writeKey(k.name)
+		return
+	}
+	enc.flushIfFull()
+	// the first field of a layout never follows another field
+	if k.first {
+		if enc.pretty {
+			enc.writeIndent(enc.depth)
+			enc.writeBytes(k.b)
+			enc.writeByte(' ')
+			return
+		}
+		enc.writeBytes(k.b)
+		return
+	}
+	r := enc.getPreviousRune()
+	if enc.pretty {
+		if r != '{' {
+			enc.writeByte(',')
+		}
+		enc.writeIndent(enc.depth)
+		enc.writeBytes(k.b[1:])
+		enc.writeByte(' ')
+		return
+	}
+	if r == '{' {
+		enc.writeBytes(k.b[1:])
+		return
+	}
+	enc.writeBytes(k.b)
+}
+
+// ObjectLayout holds the precompiled keys of an object with a fixed shape.
+// For each field, it precomputes the constant bytes written between the previous value and the new one:
+// "id": for the first field, which is written without checking for a previous field,
+// and ,"name": for the following ones.
+//
+//	var userLayout = gojay.NewObjectLayout("id", "name")
+//
+//	func (u *User) MarshalObject(enc *gojay.Encoder) {
+//		enc.AddIntK(userLayout.Key(0), u.ID)
+//		enc.AddStringKOmitEmpty(userLayout.Key(1), u.Name)
+//	}
+//
+// Key(0) must only be used for the first field of the object.
+// Following fields can use OmitEmpty methods, the separator is then dropped if no field precedes them.
+type ObjectLayout struct {
+	keys []Key
+}
+
+// NewObjectLayout returns the ObjectLayout of an object with the given keys, in order.
+func NewObjectLayout(names ...string) *ObjectLayout {
+	l := &ObjectLayout{keys: make([]Key, len(names))}
+	for i, name := range names {
+		l.keys[i] = NewKey(name)
+	}
+	if len(l.keys) > 0 {
+		l.keys[0].b = l.keys[0].b[1:]
+		l.keys[0].first = true
+	}
+	return l
+}
+
+// Key returns the precompiled key of the i-th field of the layout.
+func (l *ObjectLayout) Key(i int) Key {
+	return l.keys[i]
+}
+
+// Len returns the number of fields of the layout.
+func (l *ObjectLayout) Len() int {
+	return len(l.keys)
+}
+
+// AddStringK adds a string to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddStringK(k Key, v string) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + len(v) + 4)
+	enc.writeKeyK(k)
+	enc.writeByte('"')
+	enc.writeStringEscape(v)
+	enc.writeByte('"')
+}
+
+// AddStringKOmitEmpty adds a string to be encoded with a precompiled key or skips it if it is zero value.
+// Must be used inside an object
+func (enc *Encoder) AddStringKOmitEmpty(k Key, v string) {
+	if v == "" {
+		return
+	}
+	enc.AddStringK(k, v)
+}
+
+// AddBoolK adds a bool to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddBoolK(k Key, v bool) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 6)
+	enc.writeKeyK(k)
+	enc.buf = strconv.AppendBool(enc.buf, v)
+}
+
+// AddBoolKOmitEmpty adds a bool to be encoded with a precompiled key or skips it if it is zero value.
+// Must be used inside an object
+func (enc *Encoder) AddBoolKOmitEmpty(k Key, v bool) {
+	if !v {
+		return
+	}
+	enc.AddBoolK(k, v)
+}
+
+// AddIntK adds an int to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddIntK(k Key, v int) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 20)
+	enc.writeKeyK(k)
+	enc.writeInt(v)
+}
+
+// AddIntKOmitEmpty adds an int to be encoded with a precompiled key and skips it if its value is 0.
+// Must be used inside an object
+func (enc *Encoder) AddIntKOmitEmpty(k Key, v int) {
+	if v == 0 {
+		return
+	}
+	enc.AddIntK(k, v)
+}
+
+// AddInt64K adds an int64 to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddInt64K(k Key, v int64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 22)
+	enc.writeKeyK(k)
+	enc.writeInt64(v)
+}
+
+// AddInt64KOmitEmpty adds an int64 to be encoded with a precompiled key and skips it if its value is 0.
+// Must be used inside an object
+func (enc *Encoder) AddInt64KOmitEmpty(k Key, v int64) {
+	if v == 0 {
+		return
+	}
+	enc.AddInt64K(k, v)
+}
+
+// AddUint64K adds an uint64 to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddUint64K(k Key, v uint64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 22)
+	enc.writeKeyK(k)
+	enc.writeUint64(v)
+}
+
+// AddUint64KOmitEmpty adds an uint64 to be encoded with a precompiled key and skips it if its value is 0.
+// Must be used inside an object
+func (enc *Encoder) AddUint64KOmitEmpty(k Key, v uint64) {
+	if v == 0 {
+		return
+	}
+	enc.AddUint64K(k, v)
+}
+
+// AddFloatK adds a float64 to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddFloatK(k Key, v float64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 24)
+	enc.writeKeyK(k)
+	enc.writeFloat(v, 64)
+}
+
+// AddFloatKOmitEmpty adds a float64 to be encoded with a precompiled key and skips it if its value is 0.
+// Must be used inside an object
+func (enc *Encoder) AddFloatKOmitEmpty(k Key, v float64) {
+	if v == 0 {
+		return
+	}
+	enc.AddFloatK(k, v)
+}
+
+// AddNullK adds a null to be encoded with a precompiled key, must be used inside an object
+func (enc *Encoder) AddNullK(k Key) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 5)
+	enc.writeKeyK(k)
+	enc.writeString("null")
+}
+
+// AddObjectK adds an object to be encoded with a precompiled key, must be used inside an object
+// value must implement MarshalerObject
+func (enc *Encoder) AddObjectK(k Key, v MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 4)
+	enc.writeKeyK(k)
+	enc.writeOpen('{')
+	if !v.IsNil() {
+		v.MarshalObject(enc)
+	}
+	enc.writeClose('}')
+}
+
+// AddObjectKOmitEmpty adds an object to be encoded with a precompiled key or skips it if IsNil returns true.
+// Must be used inside an object
+func (enc *Encoder) AddObjectKOmitEmpty(k Key, v MarshalerObject) {
+	if v.IsNil() {
+		return
+	}
+	enc.AddObjectK(k, v)
+}
+
+// AddArrayK adds an array or slice to be encoded with a precompiled key, must be used inside an object
+// value must implement MarshalerArray
+func (enc *Encoder) AddArrayK(k Key, v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(k.b) + 4)
+	enc.writeKeyK(k)
+	enc.writeOpen('[')
+	if !v.IsNil() {
+		v.MarshalArray(enc)
+	}
+	enc.writeClose(']')
+}
+
+// AddArrayKOmitEmpty adds an array or slice to be encoded with a precompiled key or skips it if IsNil returns true.
+// Must be used inside an object
+func (enc *Encoder) AddArrayKOmitEmpty(k Key, v MarshalerArray) {
+	if v.IsNil() {
+		return
+	}
+	enc.AddArrayK(k, v)
+}
+
+// AddInterfaceK adds an interface{} to be encoded with a precompiled key, must be used inside an object
+//
+// Values with a dedicated K method are written with it, others are written like AddInterfaceKey does.
+func (enc *Encoder) AddInterfaceK(k Key, v interface{}) {
+	switch vt := v.(type) {
+	case string:
+		enc.AddStringK(k, vt)
+	case bool:
+		enc.AddBoolK(k, vt)
+	case MarshalerArray:
+		enc.AddArrayK(k, vt)
+	case MarshalerObject:
+		enc.AddObjectK(k, vt)
+	case int:
+		enc.AddIntK(k, vt)
+	case int64:
+		enc.AddInt64K(k, vt)
+	case uint64:
+		enc.AddUint64K(k, vt)
+	case float64:
+		enc.AddFloatK(k, vt)
+	case nil:
+		enc.AddNullK(k)
+	default:
+		if enc.err != nil {
+			return
+		}
+		enc.writeKeyK(k)
+		enc.writeInterface(vt)
+	}
+}
@@ -0,0 +1,218 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKeyLayout = NewObjectLayout("str", "bool", "int", "int64", "uint64", "float", "null", "obj", "arr", "iface", "<é>")
+
+type testKeyObject struct {
+	useKeys bool
+	empty   bool
+	sub     *testKeyObject
+}
+
+func (t *testKeyObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testKeyObject) MarshalObject(enc *Encoder) {
+	if !t.useKeys {
+		enc.AddStringKeyOmitEmpty("str", t.value("a\"b"))
+		enc.AddBoolKey("bool", true)
+		enc.AddIntKey("int", -1)
+		enc.AddInt64Key("int64", 64)
+		enc.AddUint64Key("uint64", 1<<63)
+		enc.AddFloatKey("float", 1.5)
+		enc.AddNullKey("null")
+		enc.AddObjectKeyOmitEmpty("obj", t.sub)
+		enc.AddArrayKey("arr", testKeyArray{1})
+		enc.AddInterfaceKey("iface", map[string]interface{}{"a": []string{"b"}})
+		enc.AddStringKey("<é>", "v")
+		return
+	}
+	l := testKeyLayout
+	enc.AddStringKOmitEmpty(l.Key(0), t.value("a\"b"))
+	enc.AddBoolK(l.Key(1), true)
+	enc.AddIntK(l.Key(2), -1)
+	enc.AddInt64K(l.Key(3), 64)
+	enc.AddUint64K(l.Key(4), 1<<63)
+	enc.AddFloatK(l.Key(5), 1.5)
+	enc.AddNullK(l.Key(6))
+	enc.AddObjectKOmitEmpty(l.Key(7), t.sub)
+	enc.AddArrayK(l.Key(8), testKeyArray{1})
+	enc.AddInterfaceK(l.Key(9), map[string]interface{}{"a": []string{"b"}})
+	enc.AddStringK(l.Key(10), "v")
+}
+
+type testKeyArray []int
+
+func (t testKeyArray) IsNil() bool {
+	return t == nil
+}
+
+func (t testKeyArray) MarshalArray(enc *Encoder) {
+	for _, v := range t {
+		enc.AddInt(v)
+	}
+}
+
+func (t *testKeyObject) value(v string) string {
+	if t.empty {
+		return ""
+	}
+	return v
+}
+
+func TestEncoderKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(enc *Encoder)
+		empty    bool
+		expected string
+	}{
+		{
+			name:     "basic",
+			setup:    func(enc *Encoder) {},
+			expected: `{"str":"a\"b","bool":true,"int":-1,"int64":64,"uint64":9223372036854775808,"float":1.5,"null":null,"obj":{"str":"a\"b","bool":true,"int":-1,"int64":64,"uint64":9223372036854775808,"float":1.5,"null":null,"arr":[1],"iface":{"a":["b"]},"<é>":"v"},"arr":[1],"iface":{"a":["b"]},"<é>":"v"}`,
+		},
+		{
+			name:     "omit-first",
+			setup:    func(enc *Encoder) {},
+			empty:    true,
+			expected: `{"bool":true,"int":-1,"int64":64,"uint64":9223372036854775808,"float":1.5,"null":null,"obj":{"bool":true,"int":-1,"int64":64,"uint64":9223372036854775808,"float":1.5,"null":null,"arr":[1],"iface":{"a":["b"]},"<é>":"v"},"arr":[1],"iface":{"a":["b"]},"<é>":"v"}`,
+		},
+		{
+			name:  "escape-html",
+			setup: func(enc *Encoder) { enc.SetEscapeHTML(true) },
+		},
+		{
+			name:  "ascii-only",
+			setup: func(enc *Encoder) { enc.SetASCIIOnly(true) },
+		},
+		{
+			name:  "indent",
+			setup: func(enc *Encoder) { enc.SetIndent(">", "  ") },
+		},
+		{
+			name:  "indent-omit-first",
+			setup: func(enc *Encoder) { enc.SetIndent("", "\t") },
+			empty: true,
+		},
+		{
+			name:  "flush-threshold",
+			setup: func(enc *Encoder) { enc.SetFlushThreshold(8) },
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			encode := func(useKeys bool) string {
+				builder := &strings.Builder{}
+				enc := NewEncoder(builder)
+				testCase.setup(enc)
+				sub := &testKeyObject{useKeys: useKeys, empty: testCase.empty}
+				err := enc.EncodeObject(&testKeyObject{useKeys: useKeys, empty: testCase.empty, sub: sub})
+				assert.Nil(t, err, "err should be nil")
+				return builder.String()
+			}
+			expected := encode(false)
+			if testCase.expected != "" {
+				assert.Equal(t, testCase.expected, expected, "string keys result is not expected value")
+			}
+			assert.Equal(t, expected, encode(true), "precompiled keys result should be the same as string keys result")
+		})
+	}
+}
+
+func TestEncoderKeyOmitEmpty(t *testing.T) {
+	k := NewKey("k")
+	b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKOmitEmpty(k, "")
+		enc.AddBoolKOmitEmpty(k, false)
+		enc.AddIntKOmitEmpty(k, 0)
+		enc.AddInt64KOmitEmpty(k, 0)
+		enc.AddUint64KOmitEmpty(k, 0)
+		enc.AddFloatKOmitEmpty(k, 0)
+		enc.AddObjectKOmitEmpty(k, EncodeObjectFunc(nil))
+		enc.AddArrayKOmitEmpty(k, testKeyArray(nil))
+		enc.AddBoolKOmitEmpty(k, true)
+		enc.AddIntKOmitEmpty(k, 1)
+	}))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, `{"k":true,"k":1}`, string(b), "string(b) is not expected value")
+}
+
+func TestEncoderKeyMisc(t *testing.T) {
+	t.Run("escaped", func(t *testing.T) {
+		k := NewKey("a\"\n")
+		assert.Equal(t, "a\"\n", k.String(), "k.String() is not expected value")
+		b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntK(k, 1)
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a\"\n":1}`, string(b), "string(b) is not expected value")
+	})
+	t.Run("zero-key", func(t *testing.T) {
+		b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntK(Key{}, 1)
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"":1}`, string(b), "string(b) is not expected value")
+	})
+	t.Run("layout", func(t *testing.T) {
+		l := NewObjectLayout("a", "b", "c")
+		assert.Equal(t, 3, l.Len(), "l.Len() is not expected value")
+		assert.Equal(t, "a", l.Key(0).String(), "l.Key(0).String() is not expected value")
+		assert.Equal(t, "b", l.Key(1).String(), "l.Key(1).String() is not expected value")
+		assert.Equal(t, `"a":`, string(l.Key(0).b), "first token should hold no separator")
+		assert.Equal(t, `,"b":`, string(l.Key(1).b), "following tokens should hold the separator")
+		assert.Equal(t, `,"a":`, string(NewKey("a").b), "l.Key(0) should not alter keys built by NewKey")
+	})
+	t.Run("layout-omitted-fields", func(t *testing.T) {
+		l := NewObjectLayout("a", "b", "c")
+		testCases := []struct {
+			name     string
+			a, b     int
+			expected string
+		}{
+			{name: "all", a: 1, b: 2, expected: `{"a":1,"b":2,"c":3}`},
+			{name: "first-omitted", b: 2, expected: `{"b":2,"c":3}`},
+			{name: "first-two-omitted", expected: `{"c":3}`},
+			{name: "middle-omitted", a: 1, expected: `{"a":1,"c":3}`},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+					enc.AddIntKOmitEmpty(l.Key(0), testCase.a)
+					enc.AddIntKOmitEmpty(l.Key(1), testCase.b)
+					enc.AddIntK(l.Key(2), 3)
+				}))
+				assert.Nil(t, err, "err should be nil")
+				assert.Equal(t, testCase.expected, string(b), "string(b) is not expected value")
+			})
+		}
+	})
+	t.Run("layout-indent", func(t *testing.T) {
+		l := NewObjectLayout("a", "b")
+		b, err := MarshalObjectIndent(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntK(l.Key(0), 1)
+			enc.AddIntK(l.Key(1), 2)
+		}), "", "  ")
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, "{\n  \"a\": 1,\n  \"b\": 2\n}", string(b), "string(b) is not expected value")
+	})
+	t.Run("error", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := NewEncoder(builder)
+		err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntK(NewKey("a"), 1)
+			enc.AddError(InvalidMarshalError("err"))
+			enc.AddStringK(NewKey("b"), "b")
+		}))
+		assert.NotNil(t, err, "err should not be nil")
+		assert.Equal(t, "", builder.String(), "nothing should be written")
+	})
+}
@@ -0,0 +1,201 @@
+package gojay
+
+// AddNull adds a null to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddNull() {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(5)
+	enc.writeArraySep()
+	enc.writeString("null")
+}
+
+// AddNullKey adds a null to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddNullKey(key string) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(key) + 8)
+	enc.writeKey(key)
+	enc.writeString("null")
+}
+
+// AddStringPtr adds a string pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddStringPtr(v *string) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddString(*v)
+}
+
+// AddStringPtrKey adds a string pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddStringPtrKey(key string, v *string) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddStringKey(key, *v)
+}
+
+// AddBoolPtr adds a bool pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddBoolPtr(v *bool) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddBool(*v)
+}
+
+// AddBoolPtrKey adds a bool pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddBoolPtrKey(key string, v *bool) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddBoolKey(key, *v)
+}
+
+// AddIntPtr adds an int pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddIntPtr(v *int) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddInt(*v)
+}
+
+// AddIntPtrKey adds an int pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddIntPtrKey(key string, v *int) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddIntKey(key, *v)
+}
+
+// AddInt64Ptr adds an int64 pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddInt64Ptr(v *int64) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddInt64(*v)
+}
+
+// AddInt64PtrKey adds an int64 pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddInt64PtrKey(key string, v *int64) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddInt64Key(key, *v)
+}
+
+// AddUint64Ptr adds an uint64 pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint64Ptr(v *uint64) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddUint64(*v)
+}
+
+// AddUint64PtrKey adds an uint64 pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint64PtrKey(key string, v *uint64) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddUint64Key(key, *v)
+}
+
+// AddFloatPtr adds a float64 pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddFloatPtr(v *float64) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddFloat(*v)
+}
+
+// AddFloatPtrKey adds a float64 pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddFloatPtrKey(key string, v *float64) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddFloatKey(key, *v)
+}
+
+// AddFloat32Ptr adds a float32 pointer to be encoded, null is written if v is nil.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddFloat32Ptr(v *float32) {
+	if v == nil {
+		enc.AddNull()
+		return
+	}
+	enc.AddFloat32(*v)
+}
+
+// AddFloat32PtrKey adds a float32 pointer to be encoded, null is written if v is nil.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddFloat32PtrKey(key string, v *float32) {
+	if v == nil {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddFloat32Key(key, *v)
+}
+
+// AddObjectNullEmpty adds an object to be encoded, null is written if v is nil or IsNil returns true.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddObjectNullEmpty(v MarshalerObject) {
+	if v == nil || v.IsNil() {
+		enc.AddNull()
+		return
+	}
+	enc.AddObject(v)
+}
+
+// AddObjectKeyNullEmpty adds an object to be encoded, null is written if v is nil or IsNil returns true.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddObjectKeyNullEmpty(key string, v MarshalerObject) {
+	if v == nil || v.IsNil() {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddObjectKey(key, v)
+}
+
+// AddArrayNullEmpty adds an array or slice to be encoded, null is written if v is nil or IsNil returns true.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddArrayNullEmpty(v MarshalerArray) {
+	if v == nil || v.IsNil() {
+		enc.AddNull()
+		return
+	}
+	enc.AddArray(v)
+}
+
+// AddArrayKeyNullEmpty adds an array or slice to be encoded, null is written if v is nil or IsNil returns true.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddArrayKeyNullEmpty(key string, v MarshalerArray) {
+	if v == nil || v.IsNil() {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddArrayKey(key, v)
+}
@@ -0,0 +1,187 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderNull(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddNull()
+		enc.AddInt(1)
+		enc.AddNull()
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[null,1,null]`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddNullKey("a")
+		enc.AddNullKey("b")
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":null,"b":null}`, builder.String(), "builder.String() is not expected value")
+	})
+}
+
+func TestEncoderPtr(t *testing.T) {
+	s := "hello"
+	b := true
+	i := -1
+	i64 := int64(9007199254740993)
+	u64 := uint64(18446744073709551615)
+	f := 1.5
+	f32 := float32(2.5)
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddStringPtrKey("s", &s)
+		enc.AddStringPtrKey("sn", nil)
+		enc.AddBoolPtrKey("b", &b)
+		enc.AddBoolPtrKey("bn", nil)
+		enc.AddIntPtrKey("i", &i)
+		enc.AddIntPtrKey("in", nil)
+		enc.AddInt64PtrKey("i64", &i64)
+		enc.AddInt64PtrKey("i64n", nil)
+		enc.AddUint64PtrKey("u64", &u64)
+		enc.AddUint64PtrKey("u64n", nil)
+		enc.AddFloatPtrKey("f", &f)
+		enc.AddFloatPtrKey("fn", nil)
+		enc.AddFloat32PtrKey("f32", &f32)
+		enc.AddFloat32PtrKey("f32n", nil)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"s":"hello","sn":null,"b":true,"bn":null,"i":-1,"in":null,"i64":9007199254740993,"i64n":null,`+
+				`"u64":18446744073709551615,"u64n":null,"f":1.5,"fn":null,"f32":2.5,"f32n":null}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddStringPtr(nil)
+		enc.AddStringPtr(&s)
+		enc.AddBoolPtr(&b)
+		enc.AddBoolPtr(nil)
+		enc.AddIntPtr(&i)
+		enc.AddIntPtr(nil)
+		enc.AddInt64Ptr(&i64)
+		enc.AddInt64Ptr(nil)
+		enc.AddUint64Ptr(&u64)
+		enc.AddUint64Ptr(nil)
+		enc.AddFloatPtr(&f)
+		enc.AddFloatPtr(nil)
+		enc.AddFloat32Ptr(&f32)
+		enc.AddFloat32Ptr(nil)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`[null,"hello",true,null,-1,null,9007199254740993,null,18446744073709551615,null,1.5,null,2.5,null]`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+}
+
+func TestEncoderNullEmpty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encode   func(enc *Encoder)
+		expected string
+	}{
+		{
+			name: "object-key-nil",
+			encode: func(enc *Encoder) {
+				var v *TestEncoding
+				enc.AddObjectKeyNullEmpty("o", v)
+			},
+			expected: `{"o":null}`,
+		},
+		{
+			name: "object-key-nil-interface",
+			encode: func(enc *Encoder) {
+				enc.AddObjectKeyNullEmpty("o", nil)
+			},
+			expected: `{"o":null}`,
+		},
+		{
+			name: "object-key",
+			encode: func(enc *Encoder) {
+				enc.AddObjectKeyNullEmpty("o", &SubObject{test1: 1, test2: "a"})
+			},
+			expected: `{"o":{"test1":1,"test2":"a","test3":0,"testBool":false,"sub":{}}}`,
+		},
+		{
+			name: "array-key-nil",
+			encode: func(enc *Encoder) {
+				enc.AddArrayKeyNullEmpty("a", TestEncodingArr(nil))
+			},
+			expected: `{"a":null}`,
+		},
+		{
+			name: "array-key",
+			encode: func(enc *Encoder) {
+				enc.AddArrayKeyNullEmpty("a", TestEncodingArrStrings{"x"})
+			},
+			expected: `{"a":["x"]}`,
+		},
+		{
+			name: "array-key-empty-as-nil",
+			encode: func(enc *Encoder) {
+				enc.AddArrayKeyNullEmpty("a", TestEncodingArrStrings{})
+				enc.AddArrayKey("b", TestEncodingArrStrings{})
+			},
+			expected: `{"a":null,"b":[]}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := &strings.Builder{}
+			enc := BorrowEncoder(builder)
+			defer enc.Release()
+			enc.writeByte('{')
+			testCase.encode(enc)
+			enc.writeByte('}')
+			_, err := enc.Write()
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, builder.String(), "builder.String() is not expected value")
+		})
+	}
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		var v *TestEncoding
+		enc.writeByte('[')
+		enc.AddObjectNullEmpty(v)
+		enc.AddObjectNullEmpty(nil)
+		enc.AddArrayNullEmpty(TestEncodingArr(nil))
+		enc.AddArrayNullEmpty(nil)
+		enc.AddArrayNullEmpty(TestEncodingArrStrings{"x"})
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[null,null,null,null,["x"]]`, builder.String(), "builder.String() is not expected value")
+	})
+}
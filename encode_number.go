@@ -1,6 +1,9 @@
 package gojay
 
-import "strconv"
+import (
+	"math"
+	"strconv"
+)
 
 // EncodeInt encodes an int to JSON
 func (enc *Encoder) EncodeInt(n int) error {
@@ -8,16 +11,12 @@ func (enc *Encoder) EncodeInt(n int) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeInt(n)
-	_, err := enc.Write()
-	if err != nil {
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 // encodeInt encodes an int to JSON
 func (enc *Encoder) encodeInt(n int) ([]byte, error) {
-	enc.buf = strconv.AppendInt(enc.buf, int64(n), 10)
+	enc.writeInt(n)
 	return enc.buf, nil
 }
 
@@ -27,16 +26,12 @@ func (enc *Encoder) EncodeInt64(n int64) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeInt64(n)
-	_, err := enc.Write()
-	if err != nil {
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 // encodeInt64 encodes an int to JSON
 func (enc *Encoder) encodeInt64(n int64) ([]byte, error) {
-	enc.buf = strconv.AppendInt(enc.buf, n, 10)
+	enc.writeInt64(n)
 	return enc.buf, nil
 }
 
@@ -46,17 +41,13 @@ func (enc *Encoder) EncodeFloat(n float64) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeFloat(n)
-	_, err := enc.Write()
-	if err != nil {
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 // encodeFloat encodes a float64 to JSON
 func (enc *Encoder) encodeFloat(n float64) ([]byte, error) {
-	enc.buf = strconv.AppendFloat(enc.buf, n, 'f', -1, 64)
-	return enc.buf, nil
+	enc.writeFloat(n, 64)
+	return enc.buf, enc.err
 }
 
 // EncodeFloat32 encodes a float32 to JSON
@@ -65,230 +56,315 @@ func (enc *Encoder) EncodeFloat32(n float32) error {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeFloat32(n)
-	_, err := enc.Write()
-	if err != nil {
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 func (enc *Encoder) encodeFloat32(n float32) ([]byte, error) {
-	enc.buf = strconv.AppendFloat(enc.buf, float64(n), 'f', -1, 32)
-	return enc.buf, nil
+	enc.writeFloat(float64(n), 32)
+	return enc.buf, enc.err
 }
 
 // AddInt adds an int to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddInt(v int) {
-	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.writeInt(v)
 }
 
 // AddIntOmitEmpty adds an int to be encoded and skips it if its value is 0,
 // must be used inside a slice or array encoding (does not encode a key).
 func (enc *Encoder) AddIntOmitEmpty(v int) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+	enc.writeArraySep()
+	enc.writeInt(v)
 }
 
 // AddInt64 adds an int to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddInt64(v int64) {
-	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.writeInt64(v)
 }
 
 // AddInt64OmitEmpty adds an int to be encoded and skips it if its value is 0,
 // must be used inside a slice or array encoding (does not encode a key).
 func (enc *Encoder) AddInt64OmitEmpty(v int64) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.writeArraySep()
+	enc.writeInt64(v)
 }
 
 // AddFloat adds a float64 to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddFloat(v float64) {
-	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.buf = strconv.AppendFloat(enc.buf, v, 'f', -1, 64)
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.writeFloat(v, 64)
 }
 
 // AddFloatOmitEmpty adds a float64 to be encoded and skips it if its value is 0,
 // must be used inside a slice or array encoding (does not encode a key).
 func (enc *Encoder) AddFloatOmitEmpty(v float64) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.buf = strconv.AppendFloat(enc.buf, v, 'f', -1, 64)
+	enc.writeArraySep()
+	enc.writeFloat(v, 64)
 }
 
 // AddFloat32 adds a float32 to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddFloat32(v float32) {
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
-	enc.buf = strconv.AppendFloat(enc.buf, float64(v), 'f', -1, 32)
+	enc.writeArraySep()
+	enc.writeFloat(float64(v), 32)
 }
 
 // AddFloat32OmitEmpty adds an int to be encoded and skips it if its value is 0,
 // must be used inside a slice or array encoding (does not encode a key).
 func (enc *Encoder) AddFloat32OmitEmpty(v float32) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.buf = strconv.AppendFloat(enc.buf, float64(v), 'f', -1, 32)
+	enc.writeArraySep()
+	enc.writeFloat(float64(v), 32)
 }
 
 // AddIntKey adds an int to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddIntKey(key string, v int) {
+	if enc.err != nil {
+		return
+	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+	enc.writeKey(key)
+	enc.writeInt(v)
 }
 
 // AddIntKeyOmitEmpty adds an int to be encoded and skips it if its value is 0.
 // Must be used inside an object as it will encode a key.
 func (enc *Encoder) AddIntKeyOmitEmpty(key string, v int) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' && r != '[' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+	enc.writeKey(key)
+	enc.writeInt(v)
 }
 
 // AddInt64Key adds an int64 to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddInt64Key(key string, v int64) {
+	if enc.err != nil {
+		return
+	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.writeKey(key)
+	enc.writeInt64(v)
 }
 
 // AddInt64KeyOmitEmpty adds an int64 to be encoded and skips it if its value is 0.
 // Must be used inside an object as it will encode a key.
 func (enc *Encoder) AddInt64KeyOmitEmpty(key string, v int64) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.writeKey(key)
+	enc.writeInt64(v)
 }
 
 // AddFloatKey adds a float64 to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloatKey(key string, value float64) {
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return
 	}
 	enc.grow(10)
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendFloat(enc.buf, value, 'f', -1, 64)
+	enc.writeKey(key)
+	enc.writeFloat(value, 64)
 }
 
 // AddFloatKeyOmitEmpty adds a float64 to be encoded and skips it if its value is 0.
 // Must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloatKeyOmitEmpty(key string, v float64) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendFloat(enc.buf, v, 'f', -1, 64)
+	enc.writeKey(key)
+	enc.writeFloat(v, 64)
 }
 
 // AddFloat32Key adds a float32 to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloat32Key(key string, v float32) {
+	if enc.err != nil {
+		return
+	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeByte('"')
-	enc.writeByte(':')
-	enc.buf = strconv.AppendFloat(enc.buf, float64(v), 'f', -1, 32)
+	enc.writeKey(key)
+	enc.writeFloat(float64(v), 32)
 }
 
 // AddFloat32KeyOmitEmpty adds a float64 to be encoded and skips it if its value is 0.
 // Must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloat32KeyOmitEmpty(key string, v float32) {
+	if enc.err != nil {
+		return
+	}
 	if v == 0 {
 		return
 	}
 	enc.grow(10 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKey)
-	enc.buf = strconv.AppendFloat(enc.buf, float64(v), 'f', -1, 32)
+	enc.writeKey(key)
+	enc.writeFloat(float64(v), 32)
+}
+
+// AddFloatPrec adds a float64 to be encoded with prec digits after the decimal point,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddFloatPrec(v float64, prec int) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.writeFloatFmt(v, 'f', prec, 64)
+}
+
+// AddFloatKeyPrec adds a float64 to be encoded with prec digits after the decimal point,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddFloatKeyPrec(key string, v float64, prec int) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.writeFloatFmt(v, 'f', prec, 64)
+}
+
+// NonFinitePolicy defines how an Encoder handles NaN and infinite floats,
+// which have no representation in JSON.
+type NonFinitePolicy byte
+
+const (
+	// NonFiniteError writes null and sets an UnsupportedValueError on the encoder. It is the default policy.
+	NonFiniteError NonFinitePolicy = iota
+	// NonFiniteNull writes null.
+	NonFiniteNull
+	// NonFiniteLiteral writes NaN, Infinity and -Infinity literals as produced by Python or JavaScript.
+	// The output is not valid JSON, it can be read back by a Decoder with SetAllowNonFinite(true).
+	NonFiniteLiteral
+)
+
+// SetNonFinitePolicy sets the policy used by the encoder to encode NaN and infinite floats.
+func (enc *Encoder) SetNonFinitePolicy(p NonFinitePolicy) {
+	enc.nonFinite = p
+}
+
+// SetFloatFormat sets the format and precision used to encode floats, as defined by strconv.FormatFloat:
+// fmt is one of 'f', 'e', 'E' or 'g' and prec is the number of digits, -1 for the smallest number of digits
+// necessary to represent the value exactly.
+//
+// If fmt is 0, floats are encoded like encoding/json and ECMAScript do, it is the default:
+// the shortest representation is used, in exponent notation if the absolute value is
+// lower than 1e-6 or greater than or equal to 1e21.
+//
+// Any other fmt is ignored and the current format and precision are kept.
+func (enc *Encoder) SetFloatFormat(fmt byte, prec int) {
+	switch fmt {
+	case 0, 'f', 'e', 'E', 'g':
+		enc.floatFmt = fmt
+		enc.floatPrec = prec
+	}
+}
+
+// writeFloat writes v to the buffer using the encoder's float format,
+// NaN and infinities are handled according to the encoder's NonFinitePolicy.
+func (enc *Encoder) writeFloat(v float64, bitSize int) {
+	enc.writeFloatFmt(v, enc.floatFmt, enc.floatPrec, bitSize)
+}
+
+// writeFloatFmt writes v to the buffer in the format fmt with precision prec, see SetFloatFormat.
+// NaN and infinities are handled according to the encoder's NonFinitePolicy.
+func (enc *Encoder) writeFloatFmt(v float64, fmt byte, prec int, bitSize int) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		switch enc.nonFinite {
+		case NonFiniteNull:
+			enc.writeString("null")
+		case NonFiniteLiteral:
+			if math.IsNaN(v) {
+				enc.writeString("NaN")
+			} else if v > 0 {
+				enc.writeString("Infinity")
+			} else {
+				enc.writeString("-Infinity")
+			}
+		default:
+			if enc.err == nil {
+				enc.err = UnsupportedValueError(
+					"Unsupported float value " + strconv.FormatFloat(v, 'f', -1, bitSize),
+				)
+			}
+			// keep output valid
+			enc.writeString("null")
+		}
+		return
+	}
+	if fmt != 0 {
+		enc.buf = strconv.AppendFloat(enc.buf, v, fmt, prec, bitSize)
+		return
+	}
+	// same rule as encoding/json
+	abs := math.Abs(v)
+	fmt = 'f'
+	if abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	enc.buf = strconv.AppendFloat(enc.buf, v, fmt, -1, bitSize)
+	if fmt == 'e' {
+		// clean up e-09 to e-9
+		n := len(enc.buf)
+		if n >= 4 && enc.buf[n-4] == 'e' && enc.buf[n-3] == '-' && enc.buf[n-2] == '0' {
+			enc.buf[n-2] = enc.buf[n-1]
+			enc.buf = enc.buf[:n-1]
+		}
+	}
 }
@@ -0,0 +1,192 @@
+package gojay
+
+import "strconv"
+
+// EncodeInt8 encodes an int8 to JSON
+func (enc *Encoder) EncodeInt8(n int8) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeInt8(n)
+	return enc.flush()
+}
+
+// encodeInt8 encodes an int8 to JSON
+func (enc *Encoder) encodeInt8(n int8) ([]byte, error) {
+	enc.buf = strconv.AppendInt(enc.buf, int64(n), 10)
+	return enc.buf, nil
+}
+
+// EncodeInt16 encodes an int16 to JSON
+func (enc *Encoder) EncodeInt16(n int16) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeInt16(n)
+	return enc.flush()
+}
+
+// encodeInt16 encodes an int16 to JSON
+func (enc *Encoder) encodeInt16(n int16) ([]byte, error) {
+	enc.buf = strconv.AppendInt(enc.buf, int64(n), 10)
+	return enc.buf, nil
+}
+
+// EncodeInt32 encodes an int32 to JSON
+func (enc *Encoder) EncodeInt32(n int32) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeInt32(n)
+	return enc.flush()
+}
+
+// encodeInt32 encodes an int32 to JSON
+func (enc *Encoder) encodeInt32(n int32) ([]byte, error) {
+	enc.buf = strconv.AppendInt(enc.buf, int64(n), 10)
+	return enc.buf, nil
+}
+
+// AddInt8 adds an int8 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddInt8(v int8) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt8OmitEmpty adds an int8 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddInt8OmitEmpty(v int8) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt8Key adds an int8 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddInt8Key(key string, v int8) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt8KeyOmitEmpty adds an int8 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddInt8KeyOmitEmpty(key string, v int8) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt16 adds an int16 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddInt16(v int16) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt16OmitEmpty adds an int16 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddInt16OmitEmpty(v int16) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt16Key adds an int16 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddInt16Key(key string, v int16) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt16KeyOmitEmpty adds an int16 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddInt16KeyOmitEmpty(key string, v int16) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt32 adds an int32 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddInt32(v int32) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt32OmitEmpty adds an int32 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddInt32OmitEmpty(v int32) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt32Key adds an int32 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddInt32Key(key string, v int32) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// AddInt32KeyOmitEmpty adds an int32 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddInt32KeyOmitEmpty(key string, v int32) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
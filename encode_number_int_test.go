@@ -0,0 +1,106 @@
+package gojay
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderSizedInt(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddInt8(math.MinInt8)
+		enc.AddInt8OmitEmpty(0)
+		enc.AddInt8OmitEmpty(1)
+		enc.AddInt16(math.MinInt16)
+		enc.AddInt16OmitEmpty(0)
+		enc.AddInt16OmitEmpty(2)
+		enc.AddInt32(math.MinInt32)
+		enc.AddInt32OmitEmpty(0)
+		enc.AddInt32OmitEmpty(3)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`[-128,1,-32768,2,-2147483648,3]`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddInt8Key("a", math.MaxInt8)
+		enc.AddInt8KeyOmitEmpty("b", 0)
+		enc.AddInt16Key("c", math.MaxInt16)
+		enc.AddInt16KeyOmitEmpty("d", 0)
+		enc.AddInt32Key("e", math.MaxInt32)
+		enc.AddInt32KeyOmitEmpty("f", 0)
+		enc.AddInt32KeyOmitEmpty("g", -1)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"a":127,"c":32767,"e":2147483647,"g":-1}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("not-quoted", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetQuoteInt64(true)
+		enc.writeByte('[')
+		enc.AddInt32(1)
+		enc.AddInterface(int16(2))
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[1,2]`, builder.String(), "builder.String() is not expected value")
+	})
+}
+
+func TestEncoderSizedEncode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		encode   func(enc *Encoder) error
+		expected string
+	}{
+		{name: "int8", encode: func(enc *Encoder) error { return enc.EncodeInt8(math.MinInt8) }, expected: "-128"},
+		{name: "int16", encode: func(enc *Encoder) error { return enc.EncodeInt16(math.MinInt16) }, expected: "-32768"},
+		{name: "int32", encode: func(enc *Encoder) error { return enc.EncodeInt32(math.MinInt32) }, expected: "-2147483648"},
+		{name: "uint8", encode: func(enc *Encoder) error { return enc.EncodeUint8(math.MaxUint8) }, expected: "255"},
+		{name: "uint16", encode: func(enc *Encoder) error { return enc.EncodeUint16(math.MaxUint16) }, expected: "65535"},
+		{name: "uint32", encode: func(enc *Encoder) error { return enc.EncodeUint32(math.MaxUint32) }, expected: "4294967295"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := &strings.Builder{}
+			enc := NewEncoder(builder)
+			// only 64-bit integers are quoted
+			enc.SetQuoteInt64(true)
+			err := testCase.encode(enc)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, builder.String(), "builder.String() is not expected value")
+		})
+	}
+	t.Run("pooled", func(t *testing.T) {
+		enc := BorrowEncoder(&strings.Builder{})
+		enc.Release()
+		assert.True(t, func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			_ = enc.EncodeInt8(1)
+			return
+		}(), "EncodeInt8 should panic on a released encoder")
+	})
+}
@@ -1,6 +1,8 @@
 package gojay
 
 import (
+	"encoding/json"
+	"math"
 	"strings"
 	"testing"
 
@@ -322,3 +324,205 @@ func TestAddNumberFunc(t *testing.T) {
 		assert.Equal(t, `[`, builder.String(), `builder.String() should be equal to {"test":10"`)
 	})
 }
+
+func TestEncoderNumberNonFinite(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       NonFinitePolicy
+		expectedJSON string
+		err          bool
+	}{
+		{
+			name:   "error",
+			policy: NonFiniteError,
+			// values added after the error are skipped
+			expectedJSON: `[1.5,null]`,
+			err:          true,
+		},
+		{
+			name:         "null",
+			policy:       NonFiniteNull,
+			expectedJSON: `[1.5,null,null,null,null]`,
+		},
+		{
+			name:         "literal",
+			policy:       NonFiniteLiteral,
+			expectedJSON: `[1.5,NaN,Infinity,-Infinity,NaN]`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := &strings.Builder{}
+			enc := BorrowEncoder(builder)
+			defer enc.Release()
+			enc.SetNonFinitePolicy(testCase.policy)
+			enc.writeByte('[')
+			enc.AddFloat(1.5)
+			enc.AddFloat(math.NaN())
+			enc.AddFloatOmitEmpty(math.Inf(1))
+			enc.AddFloat(math.Inf(-1))
+			enc.AddFloat32(float32(math.NaN()))
+			enc.writeByte(']')
+			_, err := enc.Write()
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expectedJSON, builder.String(), "builder.String() is not expected value")
+			if testCase.err {
+				assert.IsType(t, UnsupportedValueError(""), enc.err, "enc.err should be of type UnsupportedValueError")
+				return
+			}
+			assert.Nil(t, enc.err, "enc.err should be nil")
+		})
+	}
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetNonFinitePolicy(NonFiniteLiteral)
+		enc.writeByte('{')
+		enc.AddFloatKey("a", math.Inf(1))
+		enc.AddFloat32KeyOmitEmpty("b", float32(math.Inf(-1)))
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":Infinity,"b":-Infinity}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("encode-api", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.EncodeFloat(math.NaN())
+		assert.IsType(t, UnsupportedValueError(""), err, "err should be of type UnsupportedValueError")
+	})
+	t.Run("marshal-api", func(t *testing.T) {
+		_, err := Marshal(math.Inf(1))
+		assert.IsType(t, UnsupportedValueError(""), err, "err should be of type UnsupportedValueError")
+		b, err := Marshal(float32(1.5))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `1.5`, string(b), "b is not expected value")
+	})
+	t.Run("round-trip", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		defer enc.Release()
+		enc.SetNonFinitePolicy(NonFiniteLiteral)
+		b, err := enc.encodeFloat(math.Inf(-1))
+		assert.Nil(t, err, "err should be nil")
+		var v float64
+		dec := NewDecoder(strings.NewReader(string(b)))
+		dec.SetAllowNonFinite(true)
+		err = dec.DecodeFloat64(&v)
+		assert.Nil(t, err, "err should be nil")
+		assert.True(t, math.IsInf(v, -1), "v should be -Infinity")
+	})
+}
+
+func TestEncoderFloatFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		v        float64
+		expected string
+	}{
+		{name: "zero", v: 0, expected: "0"},
+		{name: "small", v: 1.5, expected: "1.5"},
+		{name: "large", v: 1e20, expected: "100000000000000000000"},
+		{name: "exponent-large", v: 1e21, expected: "1e+21"},
+		{name: "exponent-huge", v: 1e300, expected: "1e+300"},
+		{name: "tiny", v: 1e-6, expected: "0.000001"},
+		{name: "exponent-tiny", v: 1e-7, expected: "1e-7"},
+		{name: "exponent-negative", v: -1.25e-20, expected: "-1.25e-20"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b, err := Marshal(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, string(b), "Marshal result is not expected value")
+			// check against encoding/json
+			bJSON, err := json.Marshal(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, string(bJSON), string(b), "Marshal should be consistent with encoding/json")
+		})
+	}
+	t.Run("float32", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddFloat32(0.1)
+		enc.AddFloat32(1e-7)
+		enc.AddFloat32(3.4e38)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[0.1,1e-7,3.4e+38]`, builder.String(), "builder.String() is not expected value")
+		b, err := json.Marshal([]float32{0.1, 1e-7, 3.4e38})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, string(b), builder.String(), "float32 should be consistent with encoding/json")
+	})
+	t.Run("prec", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddFloatKeyPrec("price", 12.5, 2)
+		enc.AddFloatKeyPrec("rate", 0.123456, 3)
+		enc.AddFloatKey("raw", 0.123456)
+		enc.writeByte('}')
+		enc.writeByte('[')
+		enc.AddFloatPrec(1, 1)
+		enc.AddFloatPrec(1e-7, 0)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"price":12.50,"rate":0.123,"raw":0.123456}[1.0,0]`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("prec-non-finite", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetNonFinitePolicy(NonFiniteNull)
+		enc.writeByte('{')
+		enc.AddFloatKeyPrec("v", math.Inf(1), 2)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"v":null}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("encoder-format", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetFloatFormat('f', 3)
+		enc.writeByte('[')
+		enc.AddFloat(1)
+		enc.AddFloat32(0.5)
+		enc.AddFloatPrec(1, 1)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `[1.000,0.500,1.0]`, builder.String(), "builder.String() is not expected value")
+
+		builder.Reset()
+		enc.SetFloatFormat('e', -1)
+		err = enc.EncodeFloat(1234.5)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `1.2345e+03`, builder.String(), "builder.String() is not expected value")
+
+		builder.Reset()
+		enc.SetFloatFormat(0, 0)
+		err = enc.EncodeFloat(1e300)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `1e+300`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("encoder-format-invalid", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetFloatFormat('f', 2)
+		for _, fmt := range []byte{'b', 'x', 'X', 'G', 'z'} {
+			builder.Reset()
+			enc.SetFloatFormat(fmt, -1)
+			err := enc.EncodeFloat(1.5)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, `1.50`, builder.String(), "invalid formats should be ignored")
+		}
+	})
+}
@@ -0,0 +1,255 @@
+package gojay
+
+import "strconv"
+
+// EncodeUint64 encodes an uint64 to JSON
+func (enc *Encoder) EncodeUint64(n uint64) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeUint64(n)
+	return enc.flush()
+}
+
+// encodeUint64 encodes an uint64 to JSON
+func (enc *Encoder) encodeUint64(n uint64) ([]byte, error) {
+	enc.writeUint64(n)
+	return enc.buf, nil
+}
+
+// EncodeUint8 encodes a uint8 to JSON
+func (enc *Encoder) EncodeUint8(n uint8) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeUint8(n)
+	return enc.flush()
+}
+
+// encodeUint8 encodes a uint8 to JSON
+func (enc *Encoder) encodeUint8(n uint8) ([]byte, error) {
+	enc.buf = strconv.AppendUint(enc.buf, uint64(n), 10)
+	return enc.buf, nil
+}
+
+// EncodeUint16 encodes a uint16 to JSON
+func (enc *Encoder) EncodeUint16(n uint16) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeUint16(n)
+	return enc.flush()
+}
+
+// encodeUint16 encodes a uint16 to JSON
+func (enc *Encoder) encodeUint16(n uint16) ([]byte, error) {
+	enc.buf = strconv.AppendUint(enc.buf, uint64(n), 10)
+	return enc.buf, nil
+}
+
+// EncodeUint32 encodes a uint32 to JSON
+func (enc *Encoder) EncodeUint32(n uint32) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeUint32(n)
+	return enc.flush()
+}
+
+// encodeUint32 encodes a uint32 to JSON
+func (enc *Encoder) encodeUint32(n uint32) ([]byte, error) {
+	enc.buf = strconv.AppendUint(enc.buf, uint64(n), 10)
+	return enc.buf, nil
+}
+
+// AddUint8 adds a uint8 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint8(v uint8) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint8OmitEmpty adds a uint8 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddUint8OmitEmpty(v uint8) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint8Key adds a uint8 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint8Key(key string, v uint8) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint8KeyOmitEmpty adds a uint8 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddUint8KeyOmitEmpty(key string, v uint8) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint16 adds a uint16 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint16(v uint16) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint16OmitEmpty adds a uint16 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddUint16OmitEmpty(v uint16) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint16Key adds a uint16 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint16Key(key string, v uint16) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint16KeyOmitEmpty adds a uint16 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddUint16KeyOmitEmpty(key string, v uint16) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint32 adds a uint32 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint32(v uint32) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint32OmitEmpty adds a uint32 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddUint32OmitEmpty(v uint32) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10)
+	enc.writeArraySep()
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint32Key adds a uint32 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint32Key(key string, v uint32) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint32KeyOmitEmpty adds a uint32 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddUint32KeyOmitEmpty(key string, v uint32) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(10 + len(key))
+	enc.writeKey(key)
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
+
+// AddUint64 adds a uint64 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint64(v uint64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(20)
+	enc.writeArraySep()
+	enc.writeUint64(v)
+}
+
+// AddUint64OmitEmpty adds a uint64 to be encoded and skips it if its value is 0,
+// must be used inside a slice or array encoding (does not encode a key).
+func (enc *Encoder) AddUint64OmitEmpty(v uint64) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(20)
+	enc.writeArraySep()
+	enc.writeUint64(v)
+}
+
+// AddUint64Key adds a uint64 to be encoded, must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint64Key(key string, v uint64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(20 + len(key))
+	enc.writeKey(key)
+	enc.writeUint64(v)
+}
+
+// AddUint64KeyOmitEmpty adds a uint64 to be encoded and skips it if its value is 0.
+// Must be used inside an object as it will encode a key.
+func (enc *Encoder) AddUint64KeyOmitEmpty(key string, v uint64) {
+	if enc.err != nil {
+		return
+	}
+	if v == 0 {
+		return
+	}
+	enc.grow(20 + len(key))
+	enc.writeKey(key)
+	enc.writeUint64(v)
+}
@@ -0,0 +1,116 @@
+package gojay
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderSizedUint(t *testing.T) {
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddUint8(math.MaxUint8)
+		enc.AddUint8OmitEmpty(0)
+		enc.AddUint16(math.MaxUint16)
+		enc.AddUint16OmitEmpty(0)
+		enc.AddUint32(math.MaxUint32)
+		enc.AddUint32OmitEmpty(0)
+		enc.AddUint64(math.MaxUint64)
+		enc.AddUint64OmitEmpty(0)
+		enc.AddUint64OmitEmpty(1)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`[255,65535,4294967295,18446744073709551615,1]`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddUint8Key("a", 1)
+		enc.AddUint8KeyOmitEmpty("b", 0)
+		enc.AddUint16Key("c", 2)
+		enc.AddUint16KeyOmitEmpty("d", 0)
+		enc.AddUint32Key("e", 3)
+		enc.AddUint32KeyOmitEmpty("f", 0)
+		enc.AddUint64Key("g", math.MaxUint64)
+		enc.AddUint64KeyOmitEmpty("h", 0)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"a":1,"c":2,"e":3,"g":18446744073709551615}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("quoted", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetQuoteInt64(true)
+		enc.writeByte('{')
+		enc.AddUint64Key("a", 1)
+		enc.AddUint32Key("b", 2)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":"1","b":2}`, builder.String(), "builder.String() is not expected value")
+	})
+}
+
+func TestEncoderUint64Interface(t *testing.T) {
+	testCases := []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{name: "uint64-max", v: uint64(math.MaxUint64), expected: "18446744073709551615"},
+		{name: "uint32-max", v: uint32(math.MaxUint32), expected: "4294967295"},
+		{name: "uint16", v: uint16(42), expected: "42"},
+		{name: "int16", v: int16(-42), expected: "-42"},
+		{name: "uint8-max", v: uint8(math.MaxUint8), expected: "255"},
+		{name: "int8-min", v: int8(math.MinInt8), expected: "-128"},
+		{name: "int32-min", v: int32(math.MinInt32), expected: "-2147483648"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			b, err := Marshal(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, string(b), "Marshal result is not expected value")
+
+			builder := &strings.Builder{}
+			enc := NewEncoder(builder)
+			err = enc.Encode(testCase.v)
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, builder.String(), "Encode result is not expected value")
+
+			builder = &strings.Builder{}
+			enc = NewEncoder(builder)
+			enc.writeByte('{')
+			enc.AddInterfaceKey("k", testCase.v)
+			enc.AddInterfaceKeyOmitEmpty("e", testCase.v)
+			enc.writeByte('}')
+			_, err = enc.Write()
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(
+				t,
+				`{"k":`+testCase.expected+`,"e":`+testCase.expected+`}`,
+				builder.String(),
+				"AddInterfaceKey result is not expected value",
+			)
+		})
+	}
+}
@@ -1,8 +1,5 @@
 package gojay
 
-var objKeyStr = []byte(`":"`)
-var objKeyObj = []byte(`":{`)
-var objKeyArr = []byte(`":[`)
 var objKey = []byte(`":`)
 
 // EncodeObject encodes an object to JSON
@@ -10,113 +7,92 @@ func (enc *Encoder) EncodeObject(v MarshalerObject) error {
 	if enc.isPooled == 1 {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
-	_, err := enc.encodeObject(v)
-	if err != nil {
-		enc.err = err
-		return err
-	}
-	_, err = enc.Write()
-	if err != nil {
-		enc.err = err
-		return err
-	}
-	return nil
+	_, _ = enc.encodeObject(v)
+	return enc.flush()
 }
 
 func (enc *Encoder) encodeObject(v MarshalerObject) ([]byte, error) {
 	enc.grow(500)
-	enc.writeByte('{')
+	enc.writeOpen('{')
 	if !v.IsNil() {
 		v.MarshalObject(enc)
 	}
-	enc.writeByte('}')
+	enc.writeClose('}')
 	return enc.buf, enc.err
 }
 
 // AddObject adds an object to be encoded, must be used inside a slice or array encoding (does not encode a key)
 // value must implement MarshalerObject
 func (enc *Encoder) AddObject(v MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		enc.grow(2)
-		r := enc.getPreviousRune()
-		if r != '{' && r != '[' {
-			enc.writeByte(',')
-		}
-		enc.writeByte('{')
-		enc.writeByte('}')
+		enc.writeArraySep()
+		enc.writeOpen('{')
+		enc.writeClose('}')
 		return
 	}
 	enc.grow(4)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('{')
+	enc.writeArraySep()
+	enc.writeOpen('{')
 	v.MarshalObject(enc)
-	enc.writeByte('}')
+	enc.writeClose('}')
 }
 
 // AddObjectOmitEmpty adds an object to be encoded or skips it if IsNil returns true.
 // Must be used inside a slice or array encoding (does not encode a key)
 // value must implement MarshalerObject
 func (enc *Encoder) AddObjectOmitEmpty(v MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
 	if v.IsNil() {
 		return
 	}
 	enc.grow(2)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('{')
+	enc.writeArraySep()
+	enc.writeOpen('{')
 	v.MarshalObject(enc)
-	enc.writeByte('}')
+	enc.writeClose('}')
 }
 
 // AddObjectKey adds a struct to be encoded, must be used inside an object as it will encode a key
 // value must implement MarshalerObject
 func (enc *Encoder) AddObjectKey(key string, value MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
 	if value.IsNil() {
 		enc.grow(2 + len(key))
-		r := enc.getPreviousRune()
-		if r != '{' {
-			enc.writeByte(',')
-		}
-		enc.writeByte('"')
-		enc.writeStringEscape(key)
-		enc.writeBytes(objKeyObj)
-		enc.writeByte('}')
+		enc.writeKey(key)
+		enc.writeOpen('{')
+		enc.writeClose('}')
 		return
 	}
 	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyObj)
+	enc.writeKey(key)
+	enc.writeOpen('{')
 	value.MarshalObject(enc)
-	enc.writeByte('}')
+	enc.writeClose('}')
 }
 
 // AddObjectKeyOmitEmpty adds an object to be encoded or skips it if IsNil returns true.
 // Must be used inside a slice or array encoding (does not encode a key)
 // value must implement MarshalerObject
 func (enc *Encoder) AddObjectKeyOmitEmpty(key string, value MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
 	if value.IsNil() {
 		return
 	}
 	enc.grow(5 + len(key))
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeByte(',')
-	}
-	enc.writeByte('"')
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyObj)
+	enc.writeKey(key)
+	enc.writeOpen('{')
 	value.MarshalObject(enc)
-	enc.writeByte('}')
+	enc.writeClose('}')
 }
 
 // EncodeObjectFunc is a custom func type implementating MarshaleObject.
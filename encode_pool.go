@@ -38,6 +38,21 @@ func BorrowEncoder(w io.Writer) *Encoder {
 	enc.buf = enc.buf[:0]
 	enc.isPooled = 0
 	enc.err = nil
+	enc.nonFinite = NonFiniteError
+	enc.quoteInt64 = false
+	enc.escapeHTML = false
+	enc.asciiOnly = false
+	enc.pretty = false
+	enc.prefix = ""
+	enc.indent = ""
+	enc.depth = 0
+	enc.flushThreshold = 0
+	enc.written = 0
+	enc.last = 0
+	enc.floatFmt = 0
+	enc.floatPrec = 0
+	enc.ptrLevel = 0
+	enc.ptrSeen = nil
 	return enc
 }
 
@@ -0,0 +1,103 @@
+package gojay
+
+import "strconv"
+
+// SetQuoteInt64 enables or disables encoding of int64 and uint64 values as JSON strings, like "42".
+// On 64-bit platforms, int and uint values are quoted as well.
+// It is meant for JavaScript clients which can't represent integers above 2^53 without losing precision.
+func (enc *Encoder) SetQuoteInt64(b bool) {
+	enc.quoteInt64 = b
+}
+
+// AddInt64String adds an int64 to be encoded as a JSON string,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddInt64String(v int64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(22)
+	enc.writeArraySep()
+	enc.writeByte('"')
+	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.writeByte('"')
+}
+
+// AddInt64StringKey adds an int64 to be encoded as a JSON string,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddInt64StringKey(key string, v int64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(22 + len(key))
+	enc.writeKey(key)
+	enc.writeByte('"')
+	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+	enc.writeByte('"')
+}
+
+// AddUint64String adds an uint64 to be encoded as a JSON string,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddUint64String(v uint64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(22)
+	enc.writeArraySep()
+	enc.writeByte('"')
+	enc.buf = strconv.AppendUint(enc.buf, v, 10)
+	enc.writeByte('"')
+}
+
+// AddUint64StringKey adds an uint64 to be encoded as a JSON string,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddUint64StringKey(key string, v uint64) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(22 + len(key))
+	enc.writeKey(key)
+	enc.writeByte('"')
+	enc.buf = strconv.AppendUint(enc.buf, v, 10)
+	enc.writeByte('"')
+}
+
+// writeInt64 writes v to the buffer, quoted if the encoder is set to quote 64-bit integers.
+func (enc *Encoder) writeInt64(v int64) {
+	if enc.quoteInt64 {
+		enc.writeByte('"')
+		enc.buf = strconv.AppendInt(enc.buf, v, 10)
+		enc.writeByte('"')
+		return
+	}
+	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+}
+
+// writeUint64 writes v to the buffer, quoted if the encoder is set to quote 64-bit integers.
+func (enc *Encoder) writeUint64(v uint64) {
+	if enc.quoteInt64 {
+		enc.writeByte('"')
+		enc.buf = strconv.AppendUint(enc.buf, v, 10)
+		enc.writeByte('"')
+		return
+	}
+	enc.buf = strconv.AppendUint(enc.buf, v, 10)
+}
+
+// writeInt writes v to the buffer, int is 64 bits wide on 64-bit platforms
+// so it is quoted like an int64 if the encoder is set to quote 64-bit integers.
+func (enc *Encoder) writeInt(v int) {
+	if strconv.IntSize == 64 {
+		enc.writeInt64(int64(v))
+		return
+	}
+	enc.buf = strconv.AppendInt(enc.buf, int64(v), 10)
+}
+
+// writeUint writes v to the buffer, quoted like an uint64 on 64-bit platforms, see writeInt.
+func (enc *Encoder) writeUint(v uint) {
+	if strconv.IntSize == 64 {
+		enc.writeUint64(uint64(v))
+		return
+	}
+	enc.buf = strconv.AppendUint(enc.buf, uint64(v), 10)
+}
@@ -0,0 +1,115 @@
+package gojay
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderInt64String(t *testing.T) {
+	t.Run("key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddInt64StringKey("id", 9007199254740993)
+		enc.AddUint64StringKey("uid", 18446744073709551615)
+		enc.AddInt64Key("n", -1)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"id":"9007199254740993","uid":"18446744073709551615","n":-1}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddInt64String(-42)
+		enc.AddUint64String(42)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `["-42","42"]`, builder.String(), "builder.String() is not expected value")
+	})
+}
+
+func TestEncoderQuoteInt64(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetQuoteInt64(true)
+		enc.writeByte('{')
+		enc.AddInt64Key("a", 1)
+		enc.AddInt64KeyOmitEmpty("b", 0)
+		enc.AddInt64KeyOmitEmpty("c", 2)
+		enc.AddInt32Key("d", 3)
+		enc.AddInterfaceKey("e", int64(4))
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":"1","c":"2","d":3,"e":"4"}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("int", func(t *testing.T) {
+		if strconv.IntSize != 64 {
+			t.Skip("int is not 64 bits wide on this platform")
+		}
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetQuoteInt64(true)
+		enc.writeByte('{')
+		enc.AddIntKey("a", 1)
+		enc.AddIntKeyOmitEmpty("b", 2)
+		enc.AddInterfaceKey("c", 3)
+		enc.AddInterfaceKey("d", uint(4))
+		enc.AddInterfaceKey("e", []int{5})
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"a":"1","b":"2","c":"3","d":"4","e":["5"]}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+
+		builder = &strings.Builder{}
+		enc = NewEncoder(builder)
+		enc.SetQuoteInt64(true)
+		err = enc.Encode(42)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"42"`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("encode-api", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.SetQuoteInt64(true)
+		err := enc.EncodeInt64(-5)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"-5"`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("marshal-uint64", func(t *testing.T) {
+		b, err := Marshal(uint64(18446744073709551615))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `18446744073709551615`, string(b), "b is not expected value")
+	})
+	t.Run("pooled-encoder-reset", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		enc.SetQuoteInt64(true)
+		enc.Release()
+		enc = BorrowEncoder(nil)
+		defer enc.Release()
+		b, _ := enc.encodeInt64(1)
+		assert.Equal(t, `1`, string(b), "quoteInt64 should be reset")
+	})
+}
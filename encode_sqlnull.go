@@ -0,0 +1,237 @@
+package gojay
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// AddSQLNullString adds a *sql.NullString to be encoded, null is written if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullString(v *sql.NullString) {
+	if v == nil || !v.Valid {
+		enc.AddNull()
+		return
+	}
+	enc.AddString(v.String)
+}
+
+// AddSQLNullStringOmitEmpty adds a *sql.NullString to be encoded or skips it if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullStringOmitEmpty(v *sql.NullString) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddString(v.String)
+}
+
+// AddSQLNullStringKey adds a *sql.NullString to be encoded, null is written if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullStringKey(key string, v *sql.NullString) {
+	if v == nil || !v.Valid {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddStringKey(key, v.String)
+}
+
+// AddSQLNullStringKeyOmitEmpty adds a *sql.NullString to be encoded or skips it if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullStringKeyOmitEmpty(key string, v *sql.NullString) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddStringKey(key, v.String)
+}
+
+// AddSQLNullInt64 adds a *sql.NullInt64 to be encoded, null is written if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullInt64(v *sql.NullInt64) {
+	if v == nil || !v.Valid {
+		enc.AddNull()
+		return
+	}
+	enc.AddInt64(v.Int64)
+}
+
+// AddSQLNullInt64OmitEmpty adds a *sql.NullInt64 to be encoded or skips it if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullInt64OmitEmpty(v *sql.NullInt64) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddInt64(v.Int64)
+}
+
+// AddSQLNullInt64Key adds a *sql.NullInt64 to be encoded, null is written if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullInt64Key(key string, v *sql.NullInt64) {
+	if v == nil || !v.Valid {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddInt64Key(key, v.Int64)
+}
+
+// AddSQLNullInt64KeyOmitEmpty adds a *sql.NullInt64 to be encoded or skips it if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullInt64KeyOmitEmpty(key string, v *sql.NullInt64) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddInt64Key(key, v.Int64)
+}
+
+// AddSQLNullFloat64 adds a *sql.NullFloat64 to be encoded, null is written if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullFloat64(v *sql.NullFloat64) {
+	if v == nil || !v.Valid {
+		enc.AddNull()
+		return
+	}
+	enc.AddFloat(v.Float64)
+}
+
+// AddSQLNullFloat64OmitEmpty adds a *sql.NullFloat64 to be encoded or skips it if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullFloat64OmitEmpty(v *sql.NullFloat64) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddFloat(v.Float64)
+}
+
+// AddSQLNullFloat64Key adds a *sql.NullFloat64 to be encoded, null is written if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullFloat64Key(key string, v *sql.NullFloat64) {
+	if v == nil || !v.Valid {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddFloatKey(key, v.Float64)
+}
+
+// AddSQLNullFloat64KeyOmitEmpty adds a *sql.NullFloat64 to be encoded or skips it if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullFloat64KeyOmitEmpty(key string, v *sql.NullFloat64) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddFloatKey(key, v.Float64)
+}
+
+// AddSQLNullBool adds a *sql.NullBool to be encoded, null is written if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullBool(v *sql.NullBool) {
+	if v == nil || !v.Valid {
+		enc.AddNull()
+		return
+	}
+	enc.AddBool(v.Bool)
+}
+
+// AddSQLNullBoolOmitEmpty adds a *sql.NullBool to be encoded or skips it if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullBoolOmitEmpty(v *sql.NullBool) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddBool(v.Bool)
+}
+
+// AddSQLNullBoolKey adds a *sql.NullBool to be encoded, null is written if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullBoolKey(key string, v *sql.NullBool) {
+	if v == nil || !v.Valid {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddBoolKey(key, v.Bool)
+}
+
+// AddSQLNullBoolKeyOmitEmpty adds a *sql.NullBool to be encoded or skips it if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullBoolKeyOmitEmpty(key string, v *sql.NullBool) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddBoolKey(key, v.Bool)
+}
+
+// AddSQLNullTime adds a *sql.NullTime to be encoded as a RFC 3339 string, null is written if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullTime(v *sql.NullTime) {
+	if v == nil || !v.Valid {
+		enc.AddNull()
+		return
+	}
+	enc.AddTextMarshaler(v.Time)
+}
+
+// AddSQLNullTimeOmitEmpty adds a *sql.NullTime to be encoded as a RFC 3339 string or skips it if it is not valid.
+// Must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddSQLNullTimeOmitEmpty(v *sql.NullTime) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddTextMarshaler(v.Time)
+}
+
+// AddSQLNullTimeKey adds a *sql.NullTime to be encoded as a RFC 3339 string, null is written if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullTimeKey(key string, v *sql.NullTime) {
+	if v == nil || !v.Valid {
+		enc.AddNullKey(key)
+		return
+	}
+	enc.AddTextMarshalerKey(key, v.Time)
+}
+
+// AddSQLNullTimeKeyOmitEmpty adds a *sql.NullTime to be encoded as a RFC 3339 string or skips it if it is not valid.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddSQLNullTimeKeyOmitEmpty(key string, v *sql.NullTime) {
+	if v == nil || !v.Valid {
+		return
+	}
+	enc.AddTextMarshalerKey(key, v.Time)
+}
+
+// encodeSQLNull encodes v, which must be one of the sql null types or a pointer to it.
+func (enc *Encoder) encodeSQLNull(v interface{}) ([]byte, error) {
+	switch vt := v.(type) {
+	case sql.NullString:
+		return enc.encodeSQLNull(&vt)
+	case sql.NullInt64:
+		return enc.encodeSQLNull(&vt)
+	case sql.NullFloat64:
+		return enc.encodeSQLNull(&vt)
+	case sql.NullBool:
+		return enc.encodeSQLNull(&vt)
+	case sql.NullTime:
+		return enc.encodeSQLNull(&vt)
+	case *sql.NullString:
+		if vt != nil && vt.Valid {
+			return enc.encodeString(vt.String)
+		}
+	case *sql.NullInt64:
+		if vt != nil && vt.Valid {
+			return enc.encodeInt64(vt.Int64)
+		}
+	case *sql.NullFloat64:
+		if vt != nil && vt.Valid {
+			return enc.encodeFloat(vt.Float64)
+		}
+	case *sql.NullBool:
+		if vt != nil && vt.Valid {
+			return enc.encodeBool(vt.Bool)
+		}
+	case *sql.NullTime:
+		if vt != nil && vt.Valid {
+			return enc.encodeTextMarshaler(vt.Time)
+		}
+	default:
+		return nil, InvalidMarshalError(fmt.Sprintf(invalidMarshalErrorMsg, reflect.TypeOf(vt).String()))
+	}
+	enc.writeString("null")
+	return enc.buf, enc.err
+}
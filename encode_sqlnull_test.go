@@ -0,0 +1,121 @@
+package gojay
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSQLNullEncObj struct {
+	s sql.NullString
+	i sql.NullInt64
+	f sql.NullFloat64
+	b sql.NullBool
+	t sql.NullTime
+}
+
+func (o *testSQLNullEncObj) MarshalObject(enc *Encoder) {
+	enc.AddSQLNullStringKey("s", &o.s)
+	enc.AddSQLNullInt64Key("i", &o.i)
+	enc.AddSQLNullFloat64Key("f", &o.f)
+	enc.AddSQLNullBoolKey("b", &o.b)
+	enc.AddSQLNullTimeKey("t", &o.t)
+	enc.AddSQLNullStringKeyOmitEmpty("sOmit", &o.s)
+	enc.AddSQLNullInt64KeyOmitEmpty("iOmit", &o.i)
+	enc.AddSQLNullFloat64KeyOmitEmpty("fOmit", &o.f)
+	enc.AddSQLNullBoolKeyOmitEmpty("bOmit", &o.b)
+	enc.AddSQLNullTimeKeyOmitEmpty("tOmit", &o.t)
+}
+
+func (o *testSQLNullEncObj) IsNil() bool {
+	return o == nil
+}
+
+func TestEncoderSQLNull(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		v := &testSQLNullEncObj{
+			s: sql.NullString{String: "str", Valid: true},
+			i: sql.NullInt64{Int64: -1, Valid: true},
+			f: sql.NullFloat64{Float64: 1.5, Valid: true},
+			b: sql.NullBool{Bool: false, Valid: true},
+			t: sql.NullTime{Time: time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+		}
+		b, err := MarshalObject(v)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"s":"str","i":-1,"f":1.5,"b":false,"t":"2019-03-01T10:00:00Z",`+
+				`"sOmit":"str","iOmit":-1,"fOmit":1.5,"bOmit":false,"tOmit":"2019-03-01T10:00:00Z"}`,
+			string(b),
+			"b is not expected value",
+		)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		b, err := MarshalObject(&testSQLNullEncObj{})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"s":null,"i":null,"f":null,"b":null,"t":null}`, string(b), "b is not expected value")
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('[')
+		enc.AddSQLNullString(&sql.NullString{String: "a", Valid: true})
+		enc.AddSQLNullInt64(nil)
+		enc.AddSQLNullFloat64OmitEmpty(&sql.NullFloat64{})
+		enc.AddSQLNullBoolOmitEmpty(&sql.NullBool{Bool: true, Valid: true})
+		enc.AddSQLNullTime(&sql.NullTime{})
+		enc.AddSQLNullStringOmitEmpty(nil)
+		enc.AddSQLNullInt64OmitEmpty(&sql.NullInt64{Int64: 2, Valid: true})
+		enc.AddSQLNullFloat64(&sql.NullFloat64{Float64: 2.5, Valid: true})
+		enc.AddSQLNullBool(&sql.NullBool{})
+		enc.AddSQLNullTimeOmitEmpty(&sql.NullTime{})
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `["a",null,true,null,2,2.5,null]`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("interface", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddInterfaceKey("a", sql.NullString{String: "a", Valid: true})
+		enc.AddInterfaceKey("b", &sql.NullInt64{})
+		enc.AddInterfaceKeyOmitEmpty("c", sql.NullBool{})
+		enc.AddInterfaceKeyOmitEmpty("d", &sql.NullFloat64{Float64: 1, Valid: true})
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":"a","b":null,"d":1}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("encode-api", func(t *testing.T) {
+		testCases := []struct {
+			name         string
+			v            interface{}
+			expectedJSON string
+		}{
+			{name: "string", v: sql.NullString{String: "a", Valid: true}, expectedJSON: `"a"`},
+			{name: "int64", v: &sql.NullInt64{Int64: 1, Valid: true}, expectedJSON: `1`},
+			{name: "float64", v: sql.NullFloat64{}, expectedJSON: `null`},
+			{name: "bool", v: &sql.NullBool{Bool: true, Valid: true}, expectedJSON: `true`},
+			{name: "time", v: (*sql.NullTime)(nil), expectedJSON: `null`},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				builder := &strings.Builder{}
+				enc := BorrowEncoder(builder)
+				defer enc.Release()
+				err := enc.Encode(testCase.v)
+				assert.Nil(t, err, "err should be nil")
+				assert.Equal(t, testCase.expectedJSON, builder.String(), "builder.String() is not expected value")
+				b, err := Marshal(testCase.v)
+				assert.Nil(t, err, "err should be nil")
+				assert.Equal(t, testCase.expectedJSON, string(b), "b is not expected value")
+			})
+		}
+	})
+}
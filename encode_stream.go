@@ -1,9 +1,6 @@
 package gojay
 
-import (
-	"strconv"
-	"time"
-)
+import "time"
 
 // MarshalerStream is the interface to implement
 // to continuously encode of stream of data.
@@ -33,6 +30,8 @@ func (s *StreamEncoder) EncodeStream(m MarshalerStream) {
 		go consume(s, s, m)
 		return
 	}
+	// flushing in the middle of a value would interleave the output of consumers
+	s.flushThreshold = 0
 	// else use this Encoder only for first consumer
 	// and use new encoders for other consumers
 	// this is to avoid concurrent writing to same buffer
@@ -43,6 +42,7 @@ func (s *StreamEncoder) EncodeStream(m MarshalerStream) {
 		ss.done = s.done
 		ss.buf = make([]byte, 0, 512)
 		ss.delimiter = s.delimiter
+		ss.Encoder.copyOptions(s.Encoder)
 		go consume(s, ss, m)
 	}
 	return
@@ -125,46 +125,79 @@ func (s *StreamEncoder) Cancel(err error) {
 
 // AddObject adds an object to be encoded.
 // value must implement MarshalerObject.
+//
+// As with Encoder, Add methods are no-ops once an error is set,
+// the error cancels the stream and is returned by Err.
 func (s *StreamEncoder) AddObject(v MarshalerObject) {
+	if s.Encoder.err != nil {
+		return
+	}
 	if v.IsNil() {
 		return
 	}
-	s.Encoder.writeByte('{')
+	s.Encoder.writeOpen('{')
 	v.MarshalObject(s.Encoder)
-	s.Encoder.writeByte('}')
+	s.Encoder.writeClose('}')
 	s.Encoder.writeByte(s.delimiter)
 }
 
 // AddString adds a string to be encoded.
 func (s *StreamEncoder) AddString(v string) {
+	if s.Encoder.err != nil {
+		return
+	}
 	s.Encoder.writeByte('"')
-	s.Encoder.writeString(v)
+	s.Encoder.writeStringEscape(v)
 	s.Encoder.writeByte('"')
 	s.Encoder.writeByte(s.delimiter)
 }
 
 // AddArray adds an implementation of MarshalerArray to be encoded.
 func (s *StreamEncoder) AddArray(v MarshalerArray) {
-	s.Encoder.writeByte('[')
+	if s.Encoder.err != nil {
+		return
+	}
+	s.Encoder.writeOpen('[')
 	v.MarshalArray(s.Encoder)
-	s.Encoder.writeByte(']')
+	s.Encoder.writeClose(']')
 	s.Encoder.writeByte(s.delimiter)
 }
 
 // AddInt adds an int to be encoded.
 func (s *StreamEncoder) AddInt(value int) {
-	s.buf = strconv.AppendInt(s.buf, int64(value), 10)
+	if s.Encoder.err != nil {
+		return
+	}
+	s.Encoder.writeInt(value)
 	s.Encoder.writeByte(s.delimiter)
 }
 
 // AddFloat adds a float64 to be encoded.
 func (s *StreamEncoder) AddFloat(value float64) {
-	s.buf = strconv.AppendFloat(s.buf, value, 'f', -1, 64)
+	if s.Encoder.err != nil {
+		return
+	}
+	s.writeFloat(value, 64)
 	s.Encoder.writeByte(s.delimiter)
 }
 
 // Non exposed
 
+// copyOptions copies the encoding options of src set with the Set methods,
+// so that all the consumers of a stream encode values the same way.
+func (enc *Encoder) copyOptions(src *Encoder) {
+	enc.nonFinite = src.nonFinite
+	enc.quoteInt64 = src.quoteInt64
+	enc.escapeHTML = src.escapeHTML
+	enc.asciiOnly = src.asciiOnly
+	enc.pretty = src.pretty
+	enc.prefix = src.prefix
+	enc.indent = src.indent
+	enc.floatFmt = src.floatFmt
+	enc.floatPrec = src.floatPrec
+	enc.flushThreshold = src.flushThreshold
+}
+
 func consume(init *StreamEncoder, s *StreamEncoder, m MarshalerStream) {
 	defer s.Release()
 	for {
@@ -172,13 +205,15 @@ func consume(init *StreamEncoder, s *StreamEncoder, m MarshalerStream) {
 		case <-init.Done():
 			return
 		default:
+			written := s.written
 			m.MarshalStream(s)
 			if s.Encoder.err != nil {
 				init.Cancel(s.Encoder.err)
 				return
 			}
-			i, err := s.Encoder.Write()
-			if err != nil || i == 0 {
+			_, err := s.Encoder.Write()
+			// stop if the stream didn't encode anything
+			if err != nil || s.written == written {
 				init.Cancel(err)
 				return
 			}
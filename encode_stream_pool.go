@@ -19,6 +19,21 @@ func (s stream) BorrowEncoder(w io.Writer) *StreamEncoder {
 	streamEnc := streamEncPool.Get().(*StreamEncoder)
 	streamEnc.w = w
 	streamEnc.Encoder.err = nil
+	streamEnc.nonFinite = NonFiniteError
+	streamEnc.quoteInt64 = false
+	streamEnc.escapeHTML = false
+	streamEnc.asciiOnly = false
+	streamEnc.pretty = false
+	streamEnc.prefix = ""
+	streamEnc.indent = ""
+	streamEnc.depth = 0
+	streamEnc.flushThreshold = 0
+	streamEnc.written = 0
+	streamEnc.last = 0
+	streamEnc.floatFmt = 0
+	streamEnc.floatPrec = 0
+	streamEnc.ptrLevel = 0
+	streamEnc.ptrSeen = nil
 	streamEnc.done = make(chan struct{}, 1)
 	streamEnc.Encoder.buf = streamEnc.buf[:0]
 	streamEnc.nConsumer = 1
@@ -31,5 +46,20 @@ func (s stream) borrowEncoder(w io.Writer) *StreamEncoder {
 	streamEnc.isPooled = 0
 	streamEnc.w = w
 	streamEnc.Encoder.err = nil
+	streamEnc.nonFinite = NonFiniteError
+	streamEnc.quoteInt64 = false
+	streamEnc.escapeHTML = false
+	streamEnc.asciiOnly = false
+	streamEnc.pretty = false
+	streamEnc.prefix = ""
+	streamEnc.indent = ""
+	streamEnc.depth = 0
+	streamEnc.flushThreshold = 0
+	streamEnc.written = 0
+	streamEnc.last = 0
+	streamEnc.floatFmt = 0
+	streamEnc.floatPrec = 0
+	streamEnc.ptrLevel = 0
+	streamEnc.ptrSeen = nil
 	return streamEnc
 }
@@ -1,7 +1,9 @@
 package gojay
 
 import (
+	"math"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -333,3 +335,60 @@ func TestEncodeStream(t *testing.T) {
 		assert.Nil(t, enc.Value(""), "enc.Value should be nil")
 	})
 }
+
+type testStreamOptionsChan chan struct{}
+
+func (s testStreamOptionsChan) MarshalStream(enc *StreamEncoder) {
+	select {
+	case <-enc.Done():
+		return
+	case <-s:
+		enc.AddObject(testStreamOptionsObject)
+	}
+}
+
+var testStreamOptionsObject = EncodeObjectFunc(func(enc *Encoder) {
+	enc.AddStringKey("str", "<é>")
+	enc.AddInt64Key("int64", 1<<60)
+	enc.AddFloatKey("float", 1.5)
+	enc.AddFloatKey("nan", math.NaN())
+	enc.AddArrayKey("arr", TestEncodingArrStrings{"a"})
+})
+
+func TestEncodeStreamConsumerOptions(t *testing.T) {
+	setOptions := func(enc *Encoder) {
+		enc.SetEscapeHTML(true)
+		enc.SetASCIIOnly(true)
+		enc.SetQuoteInt64(true)
+		enc.SetFloatFormat('f', 2)
+		enc.SetNonFinitePolicy(NonFiniteNull)
+		enc.SetIndent("", " ")
+	}
+	builder := &strings.Builder{}
+	expectedEnc := NewEncoder(builder)
+	setOptions(expectedEnc)
+	err := expectedEnc.EncodeObject(testStreamOptionsObject)
+	assert.Nil(t, err, "err should be nil")
+	expectedStr := builder.String() + "\n"
+	assert.True(t, strings.Contains(expectedStr, `"\u003c\u00e9\u003e"`), "expected string should be escaped")
+
+	w := &TestWriter{target: 2000, mux: &sync.RWMutex{}}
+	enc := Stream.BorrowEncoder(w).NConsumer(20).LineDelimited()
+	setOptions(enc.Encoder)
+	w.enc = enc
+	s := testStreamOptionsChan(make(chan struct{}))
+	go enc.EncodeStream(s)
+	go func() {
+		for i := 0; i < 2000; i++ {
+			s <- struct{}{}
+		}
+	}()
+	<-enc.Done()
+	assert.Nil(t, enc.Err(), "enc.Err() should be nil")
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	assert.Len(t, w.result, 2000, "w.result should be 2000")
+	for _, b := range w.result {
+		assert.Equal(t, expectedStr, string(b), "every consumer should use the options of the stream encoder")
+	}
+}
@@ -1,17 +1,24 @@
 package gojay
 
+// SetEscapeHTML enables or disables escaping of <, > and & in strings as \u003c, \u003e and \u0026,
+// so that the output can be safely embedded inside HTML <script> tags.
+func (enc *Encoder) SetEscapeHTML(b bool) {
+	enc.escapeHTML = b
+}
+
+// SetASCIIOnly enables or disables escaping of non ASCII characters in strings as \uXXXX,
+// characters outside of the Basic Multilingual Plane are written as UTF-16 surrogate pairs.
+func (enc *Encoder) SetASCIIOnly(b bool) {
+	enc.asciiOnly = b
+}
+
 // EncodeString encodes a string to
 func (enc *Encoder) EncodeString(s string) error {
 	if enc.isPooled == 1 {
 		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
 	}
 	_, _ = enc.encodeString(s)
-	_, err := enc.Write()
-	if err != nil {
-		enc.err = err
-		return err
-	}
-	return nil
+	return enc.flush()
 }
 
 // encodeString encodes a string to
@@ -32,13 +39,12 @@ func (enc *Encoder) AppendString(v string) {
 
 // AddString adds a string to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddString(v string) {
-	enc.grow(len(v) + 4)
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeTwoBytes(',', '"')
-	} else {
-		enc.writeByte('"')
+	if enc.err != nil {
+		return
 	}
+	enc.grow(len(v) + 4)
+	enc.writeArraySep()
+	enc.writeByte('"')
 	enc.writeStringEscape(v)
 	enc.writeByte('"')
 }
@@ -46,30 +52,26 @@ func (enc *Encoder) AddString(v string) {
 // AddStringOmitEmpty adds a string to be encoded or skips it if it is zero value.
 // Must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddStringOmitEmpty(v string) {
-	if v == "" {
+	if enc.err != nil {
 		return
 	}
-	r := enc.getPreviousRune()
-	if r != '[' {
-		enc.writeTwoBytes(',', '"')
-	} else {
-		enc.writeByte('"')
+	if v == "" {
+		return
 	}
+	enc.writeArraySep()
+	enc.writeByte('"')
 	enc.writeStringEscape(v)
 	enc.writeByte('"')
 }
 
 // AddStringKey adds a string to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddStringKey(key, v string) {
-	enc.grow(len(key) + len(v) + 5)
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeTwoBytes(',', '"')
-	} else {
-		enc.writeByte('"')
+	if enc.err != nil {
+		return
 	}
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyStr)
+	enc.grow(len(key) + len(v) + 5)
+	enc.writeKey(key)
+	enc.writeByte('"')
 	enc.writeStringEscape(v)
 	enc.writeByte('"')
 }
@@ -77,18 +79,15 @@ func (enc *Encoder) AddStringKey(key, v string) {
 // AddStringKeyOmitEmpty adds a string to be encoded or skips it if it is zero value.
 // Must be used inside an object as it will encode a key
 func (enc *Encoder) AddStringKeyOmitEmpty(key, v string) {
+	if enc.err != nil {
+		return
+	}
 	if v == "" {
 		return
 	}
 	enc.grow(len(key) + len(v) + 5)
-	r := enc.getPreviousRune()
-	if r != '{' {
-		enc.writeTwoBytes(',', '"')
-	} else {
-		enc.writeByte('"')
-	}
-	enc.writeStringEscape(key)
-	enc.writeBytes(objKeyStr)
+	enc.writeKey(key)
+	enc.writeByte('"')
 	enc.writeStringEscape(v)
 	enc.writeByte('"')
 }
@@ -0,0 +1,146 @@
+package gojay
+
+// AddObjectAsString adds an object encoded as a JSON string, must be used inside a slice or array encoding (does not encode a key)
+// value must implement MarshalerObject
+//
+// The object is encoded straight into the buffer which is then escaped in place.
+func (enc *Encoder) AddObjectAsString(v MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(4)
+	enc.writeArraySep()
+	enc.writeByte('"')
+	enc.writeObjectAsString(v)
+	enc.writeByte('"')
+}
+
+// AddObjectAsStringKey adds an object encoded as a JSON string, must be used inside an object as it will encode a key
+// value must implement MarshalerObject
+//
+//	enc.AddObjectAsStringKey("body", payload) // "body":"{\"id\":1}"
+//
+// The object is encoded straight into the buffer which is then escaped in place.
+func (enc *Encoder) AddObjectAsStringKey(key string, v MarshalerObject) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(7 + len(key))
+	enc.writeKey(key)
+	enc.writeByte('"')
+	enc.writeObjectAsString(v)
+	enc.writeByte('"')
+}
+
+// AddArrayAsString adds an array encoded as a JSON string, must be used inside a slice or array encoding (does not encode a key)
+// value must implement MarshalerArray
+//
+// The array is encoded straight into the buffer which is then escaped in place.
+func (enc *Encoder) AddArrayAsString(v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(4)
+	enc.writeArraySep()
+	enc.writeByte('"')
+	enc.writeArrayAsString(v)
+	enc.writeByte('"')
+}
+
+// AddArrayAsStringKey adds an array encoded as a JSON string, must be used inside an object as it will encode a key
+// value must implement MarshalerArray
+//
+// The array is encoded straight into the buffer which is then escaped in place.
+func (enc *Encoder) AddArrayAsStringKey(key string, v MarshalerArray) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(7 + len(key))
+	enc.writeKey(key)
+	enc.writeByte('"')
+	enc.writeArrayAsString(v)
+	enc.writeByte('"')
+}
+
+func (enc *Encoder) writeObjectAsString(v MarshalerObject) {
+	start := len(enc.buf)
+	// the string content is kept compact and in the buffer until it is escaped
+	pretty, threshold := enc.pretty, enc.flushThreshold
+	enc.pretty, enc.flushThreshold = false, 0
+	enc.writeOpen('{')
+	if !v.IsNil() {
+		v.MarshalObject(enc)
+	}
+	enc.writeClose('}')
+	enc.pretty, enc.flushThreshold = pretty, threshold
+	enc.escapeFrom(start)
+}
+
+func (enc *Encoder) writeArrayAsString(v MarshalerArray) {
+	start := len(enc.buf)
+	// the string content is kept compact and in the buffer until it is escaped
+	pretty, threshold := enc.pretty, enc.flushThreshold
+	enc.pretty, enc.flushThreshold = false, 0
+	enc.writeOpen('[')
+	if !v.IsNil() {
+		v.MarshalArray(enc)
+	}
+	enc.writeClose(']')
+	enc.pretty, enc.flushThreshold = pretty, threshold
+	enc.escapeFrom(start)
+}
+
+const hex = "0123456789abcdef"
+
+// escapeFrom escapes in place, as the content of a JSON string, the bytes written to the buffer from start.
+// It grows the buffer once, then moves bytes backward so that no temporary buffer is needed.
+func (enc *Encoder) escapeFrom(start int) {
+	n := len(enc.buf)
+	extra := 0
+	for i := start; i < n; i++ {
+		switch c := enc.buf[i]; {
+		case c == '"' || c == '\\' || c == '\n' || c == '\r' || c == '\t' || c == '\b' || c == '\f':
+			extra++
+		case c < 0x20:
+			extra += 5
+		}
+	}
+	if extra == 0 {
+		return
+	}
+	enc.grow(extra)
+	enc.buf = enc.buf[:n+extra]
+	b := enc.buf
+	j := n + extra - 1
+	for i := n - 1; i >= start; i-- {
+		c := b[i]
+		switch c {
+		case '"', '\\':
+			b[j], b[j-1] = c, '\\'
+			j -= 2
+		case '\n':
+			b[j], b[j-1] = 'n', '\\'
+			j -= 2
+		case '\r':
+			b[j], b[j-1] = 'r', '\\'
+			j -= 2
+		case '\t':
+			b[j], b[j-1] = 't', '\\'
+			j -= 2
+		case '\b':
+			b[j], b[j-1] = 'b', '\\'
+			j -= 2
+		case '\f':
+			b[j], b[j-1] = 'f', '\\'
+			j -= 2
+		default:
+			if c < 0x20 {
+				b[j], b[j-1], b[j-2], b[j-3], b[j-4], b[j-5] = hex[c&0xf], hex[c>>4], '0', '0', 'u', '\\'
+				j -= 6
+				continue
+			}
+			b[j] = c
+			j--
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEncodingArrAsString struct{}
+
+func (t testEncodingArrAsString) MarshalArray(enc *Encoder) {
+	enc.AddArrayAsString(TestEncodingArrStrings{"a", "b"})
+	enc.AddObjectAsString(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddArrayAsStringKey("arr", TestEncodingArrStrings{"c"})
+	}))
+}
+
+func (t testEncodingArrAsString) IsNil() bool {
+	return false
+}
+
+func TestEncoderAsString(t *testing.T) {
+	t.Run("object-key", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("id", 1)
+			enc.AddObjectAsStringKey("body", EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddIntKey("id", 2)
+				enc.AddStringKey("str", "a\"b\\c\nd")
+			}))
+			enc.AddBoolKey("ok", true)
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`{"id":1,"body":"{\"id\":2,\"str\":\"a\\\"b\\\\c\\nd\"}","ok":true}`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.EncodeArray(testEncodingArrAsString{})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(
+			t,
+			`["[\"a\",\"b\"]","{\"arr\":\"[\\\"c\\\"]\"}"]`,
+			builder.String(),
+			"builder.String() is not expected value",
+		)
+	})
+	t.Run("nil-object", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		var v *testObject
+		err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddObjectAsStringKey("body", v)
+		}))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"body":"{}"}`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("escape-in-place", func(t *testing.T) {
+		enc := BorrowEncoder(nil)
+		defer enc.Release()
+		enc.writeString("x{\"a\x01\t\"}")
+		enc.escapeFrom(1)
+		assert.Equal(t, `x{\"a\u0001\t\"}`, string(enc.buf), "enc.buf is not expected value")
+	})
+}
@@ -0,0 +1,96 @@
+package gojay
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// EncodeTextMarshaler encodes v to JSON as a string using its MarshalText method.
+func (enc *Encoder) EncodeTextMarshaler(v encoding.TextMarshaler) error {
+	if enc.isPooled == 1 {
+		panic(InvalidUsagePooledEncoderError("Invalid usage of pooled encoder"))
+	}
+	_, _ = enc.encodeTextMarshaler(v)
+	return enc.flush()
+}
+
+func (enc *Encoder) encodeTextMarshaler(v encoding.TextMarshaler) ([]byte, error) {
+	enc.writeText(v)
+	return enc.buf, enc.err
+}
+
+// AddTextMarshaler adds a value implementing encoding.TextMarshaler to be encoded as a string,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddTextMarshaler(v encoding.TextMarshaler) {
+	if enc.err != nil {
+		return
+	}
+	enc.writeArraySep()
+	enc.writeText(v)
+}
+
+// AddTextMarshalerKey adds a value implementing encoding.TextMarshaler to be encoded as a string,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddTextMarshalerKey(key string, v encoding.TextMarshaler) {
+	if enc.err != nil {
+		return
+	}
+	enc.grow(len(key) + 5)
+	enc.writeKey(key)
+	enc.writeText(v)
+}
+
+// AddTextMarshalerKeyOmitEmpty adds a value implementing encoding.TextMarshaler to be encoded as a string,
+// it skips it if it is nil or if its text is empty.
+// Must be used inside an object as it will encode a key
+func (enc *Encoder) AddTextMarshalerKeyOmitEmpty(key string, v encoding.TextMarshaler) {
+	if enc.err != nil {
+		return
+	}
+	if isNilPointer(v) {
+		return
+	}
+	text, err := v.MarshalText()
+	if err == nil && len(text) == 0 {
+		return
+	}
+	enc.grow(len(key) + len(text) + 5)
+	enc.writeKey(key)
+	enc.writeTextBytes(text, err)
+}
+
+// writeText writes the text of v as a JSON string, or null if v is nil.
+func (enc *Encoder) writeText(v encoding.TextMarshaler) {
+	if isNilPointer(v) {
+		enc.writeString("null")
+		return
+	}
+	text, err := v.MarshalText()
+	enc.writeTextBytes(text, err)
+}
+
+// writeTextBytes writes text as a JSON string,
+// if err is not nil it is kept as the encoder's error and null is written instead.
+func (enc *Encoder) writeTextBytes(text []byte, err error) {
+	if err != nil {
+		if enc.err == nil {
+			enc.err = err
+		}
+		// keep output valid
+		enc.writeString("null")
+		return
+	}
+	enc.grow(len(text) + 2)
+	enc.writeByte('"')
+	enc.writeStringEscape(string(text))
+	enc.writeByte('"')
+}
+
+// isNilPointer reports whether v is nil or holds a nil pointer.
+func isNilPointer(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
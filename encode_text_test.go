@@ -0,0 +1,81 @@
+package gojay
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextEncObj struct {
+	level testLevel
+	ip    net.IP
+	ptr   *testLevel
+}
+
+func (o *testTextEncObj) MarshalObject(enc *Encoder) {
+	enc.AddTextMarshalerKey("level", o.level)
+	enc.AddTextMarshalerKey("ip", o.ip)
+	enc.AddTextMarshalerKeyOmitEmpty("ipOmit", o.ip)
+	enc.AddTextMarshalerKeyOmitEmpty("ptr", o.ptr)
+}
+
+func (o *testTextEncObj) IsNil() bool {
+	return o == nil
+}
+
+func TestEncoderTextMarshaler(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		b, err := MarshalObject(&testTextEncObj{level: testLevelError, ip: net.ParseIP("10.0.0.1")})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"level":"error","ip":"10.0.0.1","ipOmit":"10.0.0.1"}`, string(b), "b is not expected value")
+	})
+	t.Run("omit-empty", func(t *testing.T) {
+		b, err := MarshalObject(&testTextEncObj{})
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"level":"debug","ip":""}`, string(b), "b is not expected value")
+	})
+	t.Run("array", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		var nilLevel *testLevel
+		enc.writeByte('[')
+		enc.AddTextMarshaler(testLevelInfo)
+		enc.AddTextMarshaler(nilLevel)
+		enc.AddInterface(testLevelDebug)
+		enc.writeByte(']')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `["info",null,"debug"]`, builder.String(), "builder.String() is not expected value")
+	})
+	t.Run("error", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		enc.writeByte('{')
+		enc.AddInterfaceKey("a", testLevel(42))
+		enc.AddInterfaceKeyOmitEmpty("b", testLevelInfo)
+		enc.writeByte('}')
+		_, err := enc.Write()
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `{"a":null}`, builder.String(), "values added after the error should be skipped")
+		assert.NotNil(t, enc.err, "enc.err should not be nil")
+	})
+	t.Run("marshal-api", func(t *testing.T) {
+		b, err := Marshal(net.ParseIP("::1"))
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"::1"`, string(b), "b is not expected value")
+		_, err = Marshal(testLevel(42))
+		assert.NotNil(t, err, "err should not be nil")
+	})
+	t.Run("encode-api", func(t *testing.T) {
+		builder := &strings.Builder{}
+		enc := BorrowEncoder(builder)
+		defer enc.Release()
+		err := enc.Encode(testLevelError)
+		assert.Nil(t, err, "err should be nil")
+		assert.Equal(t, `"error"`, builder.String(), "builder.String() is not expected value")
+	})
+}
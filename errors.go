@@ -1,5 +1,11 @@
 package gojay
 
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
 const invalidJSONCharErrorMsg = "Invalid JSON character %c found at position %d"
 
 // InvalidJSONError is a type representing an error returned when
@@ -38,6 +44,14 @@ func (err InvalidMarshalError) Error() string {
 	return string(err)
 }
 
+// UnsupportedValueError is a type representing an error returned when
+// Encoding encounters a value which can't be represented in JSON
+type UnsupportedValueError string
+
+func (err UnsupportedValueError) Error() string {
+	return string(err)
+}
+
 // NoReaderError is a type representing an error returned when
 // decoding requires a reader and none was given
 type NoReaderError string
@@ -46,6 +60,14 @@ func (err NoReaderError) Error() string {
 	return string(err)
 }
 
+// NoWriterError is a type representing an error returned when
+// encoding requires a writer and none was given
+type NoWriterError string
+
+func (err NoWriterError) Error() string {
+	return string(err)
+}
+
 // InvalidUsagePooledDecoderError is a type representing an error returned
 // when decoding is called on a still pooled Decoder
 type InvalidUsagePooledDecoderError string
@@ -61,3 +83,80 @@ type InvalidUsagePooledEncoderError string
 func (err InvalidUsagePooledEncoderError) Error() string {
 	return string(err)
 }
+
+// ArrayElementError is a type representing an error returned when
+// an element of an array decoded in parallel could not be decoded.
+// Index is the index of the element in the array.
+type ArrayElementError struct {
+	Index int
+	Err   error
+}
+
+func (err ArrayElementError) Error() string {
+	return fmt.Sprintf("Error decoding array element %d: %s", err.Index, err.Err.Error())
+}
+
+// MarshalerError is a type representing an error returned when
+// the MarshalJSON method of a value returns an error or invalid JSON.
+type MarshalerError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (err MarshalerError) Error() string {
+	return fmt.Sprintf("Error calling MarshalJSON for type %s: %s", err.Type, err.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (err MarshalerError) Unwrap() error {
+	return err.Err
+}
+
+// TupleArityError is a type representing an error returned when
+// the number of elements of a JSON array decoded to an UnmarshalerTuple
+// differs from its arity.
+type TupleArityError struct {
+	Expected int
+	Got      int
+}
+
+func (err TupleArityError) Error() string {
+	return fmt.Sprintf("Invalid tuple arity, expected %d elements, got %d", err.Expected, err.Got)
+}
+
+// MismatchError is a type representing an error returned when decoding
+// with the FailFast or CollectAll error policy encounters a JSON value
+// which can't be decoded to the receiver type.
+// Path is the JSONPath of the value and Pos its position in the decoder's buffer.
+type MismatchError struct {
+	Path string
+	Pos  int
+	Err  error
+}
+
+func (err MismatchError) Error() string {
+	return fmt.Sprintf("%s at %s (pos %d)", err.Err.Error(), err.Path, err.Pos)
+}
+
+// Unwrap returns the underlying error.
+func (err MismatchError) Unwrap() error {
+	return err.Err
+}
+
+// MultiError is a type representing the list of errors returned when decoding
+// with the CollectAll error policy.
+type MultiError []error
+
+func (err MultiError) Error() string {
+	if len(err) == 1 {
+		return err[0].Error()
+	}
+	b := make([]byte, 0, 64)
+	b = append(b, strconv.Itoa(len(err))...)
+	b = append(b, " errors occurred:"...)
+	for _, e := range err {
+		b = append(b, "\n\t* "...)
+		b = append(b, e.Error()...)
+	}
+	return string(b)
+}